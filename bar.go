@@ -0,0 +1,136 @@
+package colorpicker
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+// Orientation represents how the bars are laid out against the area of the picker.
+type Orientation int
+
+const (
+	// OrientationVertical is orientation to display vertical bars to the right of the area.
+	OrientationVertical Orientation = iota
+	// OrientationHorizontal is orientation to display horizontal bars beneath the area.
+	OrientationHorizontal
+)
+
+// barColorFunc returns the color of a bar at the value v in the range [0, 1].
+type barColorFunc func(v float64) color.NRGBA
+
+// pickerBar is a bar of a picker, which selects a value in the range [0, 1] along its length.
+// A vertical bar has the value 0 at the top, or at the bottom if it is ascending,
+// and a horizontal bar always has the value 0 at the left.
+type pickerBar struct {
+	raster     *scrollableRaster
+	marker     barMarker
+	horizontal bool
+	ascending  bool
+
+	size     fyne.Size
+	selected float64
+
+	selectedChanged func(float64)
+}
+
+func newPickerBar(o *options, ascending bool, barColor barColorFunc, scrollStep func() float64, changed func(float64)) *pickerBar {
+	b := &pickerBar{
+		horizontal:      o.orientation == OrientationHorizontal,
+		ascending:       ascending,
+		selectedChanged: changed,
+	}
+	b.raster = newScrollableRaster(b.pixelColor(barColor), scrollStep)
+	b.raster.tapped = func(p fyne.Position) {
+		b.selectValue(b.valueAt(p))
+	}
+	b.raster.moved = func(dx, dy float64) {
+		b.selectValue(clamp01(b.selected + b.delta(dx, dy)))
+	}
+	b.marker = newDefaultBarMarker(o.markerStyle)
+	return b
+}
+
+func (b *pickerBar) object() fyne.CanvasObject {
+	return container.NewWithoutLayout(b.raster, b.marker.object())
+}
+
+func (b *pickerBar) resize(size fyne.Size) {
+	b.size = size
+	b.raster.Resize(size)
+	if b.horizontal {
+		b.marker.setBarSize(size, size.Height)
+	} else {
+		b.marker.setBarSize(size, size.Width)
+	}
+	b.setValue(b.selected)
+}
+
+// setValue moves the marker to v without calling selectedChanged.
+func (b *pickerBar) setValue(v float64) {
+	b.selected = v
+	b.marker.setPosition(b.markerPosition(v))
+	b.marker.Refresh()
+}
+
+func (b *pickerBar) selectValue(v float64) {
+	b.setValue(v)
+	b.selectedChanged(v)
+}
+
+func (b *pickerBar) setBarColor(barColor barColorFunc) {
+	b.raster.setPixelColor(b.pixelColor(barColor))
+	b.raster.Refresh()
+}
+
+func (b *pickerBar) valueAt(p fyne.Position) float64 {
+	if b.horizontal {
+		return clamp01(float64(p.X / b.size.Width))
+	}
+	v := clamp01(float64(p.Y / b.size.Height))
+	if b.ascending {
+		return 1 - v
+	}
+	return v
+}
+
+func (b *pickerBar) markerPosition(v float64) fyne.Position {
+	if b.horizontal {
+		return fyne.NewPos(b.size.Width*float32(v), b.size.Height/2)
+	}
+	if b.ascending {
+		v = 1 - v
+	}
+	return fyne.NewPos(b.size.Width/2, b.size.Height*float32(v))
+}
+
+// delta returns the change of the value moved by the keys or scrolling,
+// so that the marker follows the arrow keys and scrolling up moves it up or to the right.
+func (b *pickerBar) delta(dx, dy float64) float64 {
+	switch {
+	case b.horizontal && dx != 0:
+		return dx
+	case b.horizontal || b.ascending:
+		return -dy
+	default:
+		return dy
+	}
+}
+
+func (b *pickerBar) pixelColor(barColor barColorFunc) pixelColorFunc {
+	switch {
+	case b.horizontal:
+		return func(x, y, w, h int) color.NRGBA {
+			return barColor(float64(x) / float64(w))
+		}
+	case b.ascending:
+		return func(x, y, w, h int) color.NRGBA {
+			return barColor(1 - float64(y)/float64(h))
+		}
+	default:
+		return func(x, y, w, h int) color.NRGBA {
+			return barColor(float64(y) / float64(h))
+		}
+	}
+}
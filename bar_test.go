@@ -0,0 +1,94 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestPickerBar(t *testing.T) {
+	test.NewTempApp(t)
+	gray := func(v float64) color.NRGBA {
+		return fromFloatNRGBA(v, v, v, 1)
+	}
+	tests := []struct {
+		orientation Orientation
+		ascending   bool
+		size        fyne.Size
+		// tap is the position tapped to select 0.25, and marker is the position of the marker at 0.25.
+		tap, marker fyne.Position
+		// pixel is the pixel of the value 0.25 in the raster of 100 x 100 pixels.
+		pixelX, pixelY int
+		// right and up are the values after the right and up keys from 0.25.
+		right, up float64
+	}{
+		{OrientationVertical, false, fyne.NewSize(10, 100), fyne.NewPos(3, 25), fyne.NewPos(5, 25), 50, 25, 0.25, 0.24},
+		{OrientationVertical, true, fyne.NewSize(10, 100), fyne.NewPos(3, 75), fyne.NewPos(5, 75), 50, 75, 0.25, 0.26},
+		{OrientationHorizontal, false, fyne.NewSize(100, 10), fyne.NewPos(25, 3), fyne.NewPos(25, 5), 25, 50, 0.26, 0.26},
+		{OrientationHorizontal, true, fyne.NewSize(100, 10), fyne.NewPos(25, 3), fyne.NewPos(25, 5), 25, 50, 0.26, 0.26},
+	}
+	for _, tt := range tests {
+		var selected float64
+		o := newOptions(WithOrientation(tt.orientation))
+		b := newPickerBar(o, tt.ascending, gray, func() float64 { return defaultScrollStep }, func(v float64) {
+			selected = v
+		})
+		b.resize(tt.size)
+
+		test.TapAt(b.raster, tt.tap)
+		if selected != 0.25 {
+			t.Errorf("%v, ascending %v: tapped %v = %f; want 0.25", tt.orientation, tt.ascending, tt.tap, selected)
+		}
+		if got := b.marker.position(); got != tt.marker {
+			t.Errorf("%v, ascending %v: marker = %v; want %v", tt.orientation, tt.ascending, got, tt.marker)
+		}
+		if got := b.pixelColor(gray)(tt.pixelX, tt.pixelY, 100, 100); got != gray(0.25) {
+			t.Errorf("%v, ascending %v: pixel (%d, %d) = %v; want %v", tt.orientation, tt.ascending, tt.pixelX, tt.pixelY, got, gray(0.25))
+		}
+
+		b.raster.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+		if notEquals(selected, tt.right) {
+			t.Errorf("%v, ascending %v: right key = %f; want %f", tt.orientation, tt.ascending, selected, tt.right)
+		}
+		b.setValue(0.25)
+		b.raster.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+		if notEquals(selected, tt.up) {
+			t.Errorf("%v, ascending %v: up key = %f; want %f", tt.orientation, tt.ascending, selected, tt.up)
+		}
+	}
+}
+
+func TestPickerHorizontal(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := NewWithOptions(style, WithSize(100), WithOrientation(OrientationHorizontal))
+		vertical := New(100, style).MinSize()
+		if got, want := p.MinSize(), fyne.NewSize(vertical.Height, vertical.Width); got != want {
+			t.Errorf("style %d: MinSize() = %v; want %v", style, got, want)
+		}
+	}
+
+	p := NewWithOptions(StyleHue, WithSize(100), WithOrientation(OrientationHorizontal)).(*defaultHueColorPicker)
+	p.SetHSVA(0.5, 1, 1, 1)
+	if got := p.hueBar.raster.Size(); got != fyne.NewSize(100, 10) {
+		t.Errorf("hue bar size = %v; want 100x10", got)
+	}
+	if got := p.alphaPickerBar.raster.Size(); got != fyne.NewSize(100, 10) {
+		t.Errorf("alpha bar size = %v; want 100x10", got)
+	}
+	if got := p.CanvasObject.(*fyne.Container).Objects[2].Position(); got != fyne.NewPos(0, 110+theme.Padding()*2) {
+		t.Errorf("alpha bar position = %v; want beneath the hue bar", got)
+	}
+
+	test.TapAt(p.hueBar.raster, fyne.NewPos(25, 5))
+	test.TapAt(p.alphaPickerBar.raster, fyne.NewPos(75, 5))
+	if h, _, _, a := p.HSVA(); h != 0.25 || a != 0.75 {
+		t.Errorf("HSVA() = %f, _, _, %f; want 0.25, 0.75", h, a)
+	}
+	if got := p.alphaPickerBar.marker.position(); got != fyne.NewPos(75, 5) {
+		t.Errorf("alpha marker = %v; want (75, 5)", got)
+	}
+}
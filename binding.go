@@ -0,0 +1,156 @@
+package colorpicker
+
+import (
+	"errors"
+	"image/color"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+var errNotColor = errors.New("value is not a color.Color")
+
+// ColorBinding supports binding a color.Color value, which is nil until it is set.
+type ColorBinding = binding.Item[color.Color]
+
+// NewColorBinding returns a bindable color value that is managed internally.
+// Colors are considered equal if they have the same RGBA values, so setting an equal color of another model is not notified.
+func NewColorBinding() ColorBinding {
+	return binding.NewItem(colorEquals)
+}
+
+// BindColor returns a bindable color value that controls the contents of the provided variable.
+// If your code changes the content of the variable you should call Reload() to inform the bindings.
+func BindColor(c *color.Color) binding.ExternalItem[color.Color] {
+	return binding.BindItem(c, colorEquals)
+}
+
+// ColorToUntyped returns an Untyped binding that stays in sync with data,
+// so that a color can be passed through APIs that only accept Untyped.
+func ColorToUntyped(data ColorBinding) binding.Untyped {
+	return &untypedFromColor{from: data}
+}
+
+// UntypedToColor returns a ColorBinding that stays in sync with data, which must hold a color.Color or nil.
+func UntypedToColor(data binding.Untyped) ColorBinding {
+	return &colorFromUntyped{from: data}
+}
+
+// BoundColorPicker represents a ColorPicker whose color is bound to data.
+type BoundColorPicker interface {
+	ColorPicker
+
+	// Unbind stops following data and setting the color to it, so that the picker can be discarded.
+	Unbind()
+}
+
+// unbindSettable is implemented by the pickers of this package.
+type unbindSettable interface {
+	BoundColorPicker
+	setUnbind(func())
+}
+
+// NewWithData returns color picker container whose color is bound to data.
+// Pickers bound to the same data stay in sync. If data holds no color, it is set to the color of the picker.
+// The picker stays reachable from data until it is unbound.
+func NewWithData(style PickerStyle, data ColorBinding, opts ...Option) BoundColorPicker {
+	p := NewWithOptions(style, opts...).(unbindSettable)
+	p.setUnbind(bindPicker(p, data))
+	return p
+}
+
+// bindPicker keeps the color of p in sync with data, and returns a function to unbind them.
+func bindPicker(p ColorPicker, data ColorBinding) (unbind func()) {
+	// updating is true while the color of data is being set on p, which must not be set back to data,
+	// as the color of p may differ slightly from data after conversion
+	updating := false
+	bound := true
+	listener := binding.NewDataListener(func() {
+		c, err := data.Get()
+		if err != nil || colorEquals(c, p.Color()) {
+			return
+		}
+		if c == nil {
+			data.Set(p.Color())
+			return
+		}
+		updating = true
+		defer func() { updating = false }()
+		p.SetColor(c)
+	})
+	if l, ok := p.(changeListenable); ok {
+		l.addChangeListener(func(c color.Color) {
+			if bound && !updating {
+				data.Set(c)
+			}
+		})
+	}
+	data.AddListener(listener)
+	return func() {
+		bound = false
+		data.RemoveListener(listener)
+	}
+}
+
+func colorEquals(c1, c2 color.Color) bool {
+	if c1 == nil || c2 == nil {
+		return c1 == c2
+	}
+	r1, g1, b1, a1 := c1.RGBA()
+	r2, g2, b2, a2 := c2.RGBA()
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+}
+
+type untypedFromColor struct {
+	from ColorBinding
+}
+
+func (b *untypedFromColor) Get() (any, error) {
+	return b.from.Get()
+}
+
+func (b *untypedFromColor) Set(v any) error {
+	if v == nil {
+		return b.from.Set(nil)
+	}
+	c, ok := v.(color.Color)
+	if !ok {
+		return errNotColor
+	}
+	return b.from.Set(c)
+}
+
+func (b *untypedFromColor) AddListener(l binding.DataListener) {
+	b.from.AddListener(l)
+}
+
+func (b *untypedFromColor) RemoveListener(l binding.DataListener) {
+	b.from.RemoveListener(l)
+}
+
+type colorFromUntyped struct {
+	from binding.Untyped
+}
+
+func (b *colorFromUntyped) Get() (color.Color, error) {
+	v, err := b.from.Get()
+	if err != nil || v == nil {
+		return nil, err
+	}
+	c, ok := v.(color.Color)
+	if !ok {
+		return nil, errNotColor
+	}
+	return c, nil
+}
+
+func (b *colorFromUntyped) Set(c color.Color) error {
+	return b.from.Set(c)
+}
+
+func (b *colorFromUntyped) AddListener(l binding.DataListener) {
+	b.from.AddListener(l)
+}
+
+func (b *colorFromUntyped) RemoveListener(l binding.DataListener) {
+	b.from.RemoveListener(l)
+}
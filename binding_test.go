@@ -0,0 +1,143 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestNewWithData(t *testing.T) {
+	test.NewTempApp(t)
+	data := NewColorBinding()
+	p1 := NewWithData(StyleHue, data)
+	if c, _ := data.Get(); !colorEquals(c, p1.Color()) {
+		t.Errorf("data = %v; want the color of the picker %v", c, p1.Color())
+	}
+
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	data.Set(red)
+	if c := p1.Color(); !colorEquals(c, red) {
+		t.Errorf("Color() = %v after data.Set; want %v", c, red)
+	}
+	p1.SetColor(color.NRGBA{B: 0xff, A: 0x80})
+	if c, _ := data.Get(); !colorEquals(c, p1.Color()) {
+		t.Errorf("data = %v after SetColor; want %v", c, p1.Color())
+	}
+}
+
+func TestNewWithDataUnbind(t *testing.T) {
+	test.NewTempApp(t)
+	data := NewColorBinding()
+	p := NewWithData(StyleHue, data)
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	data.Set(red)
+
+	p.Unbind()
+	data.Set(color.Black)
+	if c := p.Color(); !colorEquals(c, red) {
+		t.Errorf("Color() = %v after Unbind and data.Set; want %v", c, red)
+	}
+	p.SetColor(color.White)
+	if c, _ := data.Get(); !colorEquals(c, color.Black) {
+		t.Errorf("data = %v after Unbind and SetColor; want black", c)
+	}
+}
+
+func TestNewWithDataKeepsPickersInSync(t *testing.T) {
+	test.NewTempApp(t)
+	data := NewColorBinding()
+	p1 := NewWithData(StyleHue, data)
+	p2 := NewWithData(StyleOKLCH, data)
+	var changed1, changed2 int
+	p1.SetOnChanged(func(color.Color) { changed1++ })
+	p2.SetOnChanged(func(color.Color) { changed2++ })
+
+	c := color.NRGBA{R: 0x33, G: 0x66, B: 0x99, A: 0xff}
+	p1.SetColor(c)
+	if got := color.NRGBAModel.Convert(p2.Color()); got != c {
+		t.Errorf("p2.Color() = %v; want %v", got, c)
+	}
+	// the color set on p2 is not set back to data, even if it differs slightly after conversion
+	if changed1 != 1 || changed2 != 1 {
+		t.Errorf("changed %d and %d times; want once each", changed1, changed2)
+	}
+	if got, _ := data.Get(); got != c {
+		t.Errorf("data = %v; want %v", got, c)
+	}
+}
+
+func TestColorUntyped(t *testing.T) {
+	test.NewTempApp(t)
+	data := NewColorBinding()
+	untyped := ColorToUntyped(data)
+	called := 0
+	untyped.AddListener(binding.NewDataListener(func() { called++ }))
+
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	if err := untyped.Set(red); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := data.Get(); c != red {
+		t.Errorf("data = %v; want %v", c, red)
+	}
+	if called != 2 {
+		t.Errorf("listener called %d times; want 2", called)
+	}
+	if err := untyped.Set("red"); err == nil {
+		t.Error("Set(\"red\") succeeded; want error")
+	}
+
+	back := UntypedToColor(untyped)
+	if c, err := back.Get(); err != nil || c != red {
+		t.Errorf("Get() = %v, %v; want %v", c, err, red)
+	}
+	other := binding.NewUntyped()
+	other.Set(1)
+	if _, err := UntypedToColor(other).Get(); err == nil {
+		t.Error("Get() of int succeeded; want error")
+	}
+}
+
+func TestColorSelectModalRectWithData(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	data := NewColorBinding()
+	rect := NewColorSelectModalRectWithData(w, fyne.NewSize(20, 20), data).(*colorSelectModalRect)
+
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	data.Set(red)
+	if c := rect.color(); c != red {
+		t.Errorf("rect color = %v; want %v", c, red)
+	}
+	blue := color.NRGBA{B: 0xff, A: 0xff}
+	rect.SetColor(blue)
+	if c, _ := data.Get(); c != blue {
+		t.Errorf("data = %v; want %v", c, blue)
+	}
+
+	rect.Unbind()
+	data.Set(red)
+	if c := rect.color(); c != blue {
+		t.Errorf("rect color = %v after Unbind; want %v", c, blue)
+	}
+	rect.SetColor(color.Black)
+	if c, _ := data.Get(); c != red {
+		t.Errorf("data = %v after Unbind; want %v", c, red)
+	}
+}
+
+func TestColorSelectModalRectWithNilData(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	data := NewColorBinding()
+	rect := NewColorSelectModalRectWithData(w, fyne.NewSize(20, 20), data)
+	if c, _ := data.Get(); !colorEquals(c, transparent) {
+		t.Errorf("data = %v; want the color of the rect", c)
+	}
+	rect.Unbind()
+}
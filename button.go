@@ -0,0 +1,228 @@
+package colorpicker
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/lusingander/colorpicker/csscolor"
+)
+
+// ColorButton is a themed button that displays a swatch of its color with an optional label,
+// and opens a color picker dialog when tapped. It can be used wherever NewColorSelectModalRect is.
+type ColorButton struct {
+	widget.DisableableWidget
+	*pickerOpener
+
+	color        color.Color
+	notation     csscolor.Notation
+	labelVisible bool
+
+	hovered, pressed, focused bool
+}
+
+// NewColorButton returns a button of color c, which opens a color picker dialog over parent when tapped.
+// The label displays the color in NotationHex by default.
+func NewColorButton(parent fyne.Window, c color.Color) *ColorButton {
+	b := &ColorButton{
+		pickerOpener: &pickerOpener{parent: parent, pickerStyle: StyleHue},
+		color:        c,
+		notation:     csscolor.NotationHex,
+		labelVisible: true,
+	}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+// Color returns the color of the button.
+func (b *ColorButton) Color() color.Color {
+	return b.color
+}
+
+func (b *ColorButton) SetColor(c color.Color) {
+	b.color = c
+	b.Refresh()
+}
+
+// SetNotation sets the notation the label displays the color in, such as csscolor.NotationNamed for the name.
+func (b *ColorButton) SetNotation(n csscolor.Notation) {
+	b.notation = n
+	b.Refresh()
+}
+
+// SetLabelVisible sets whether the label is displayed next to the swatch.
+func (b *ColorButton) SetLabelVisible(visible bool) {
+	b.labelVisible = visible
+	b.Refresh()
+}
+
+func (b *ColorButton) label() string {
+	if !b.labelVisible {
+		return ""
+	}
+	return csscolor.Format(b.color, b.notation)
+}
+
+func (b *ColorButton) Tapped(*fyne.PointEvent) {
+	if b.Disabled() {
+		return
+	}
+	if c := fyne.CurrentApp().Driver().CanvasForObject(b); c != nil {
+		c.Focus(b)
+	}
+	b.showDialog(b.color, b.SetColor)
+}
+
+func (b *ColorButton) MouseIn(*desktop.MouseEvent) {
+	b.hovered = true
+	b.Refresh()
+}
+
+func (b *ColorButton) MouseMoved(*desktop.MouseEvent) {}
+
+func (b *ColorButton) MouseOut() {
+	b.hovered = false
+	b.pressed = false
+	b.Refresh()
+}
+
+func (b *ColorButton) MouseDown(*desktop.MouseEvent) {
+	if b.Disabled() {
+		return
+	}
+	b.pressed = true
+	b.Refresh()
+}
+
+func (b *ColorButton) MouseUp(*desktop.MouseEvent) {
+	b.pressed = false
+	b.Refresh()
+}
+
+func (b *ColorButton) FocusGained() {
+	b.focused = true
+	b.Refresh()
+}
+
+func (b *ColorButton) FocusLost() {
+	b.focused = false
+	b.Refresh()
+}
+
+func (b *ColorButton) TypedRune(rune) {}
+
+func (b *ColorButton) TypedKey(e *fyne.KeyEvent) {
+	if e.Name == fyne.KeySpace || e.Name == fyne.KeyReturn || e.Name == fyne.KeyEnter {
+		b.Tapped(&fyne.PointEvent{})
+	}
+}
+
+func (b *ColorButton) Cursor() desktop.Cursor {
+	if b.Disabled() {
+		return desktop.DefaultCursor
+	}
+	return desktop.PointerCursor
+}
+
+func (b *ColorButton) CreateRenderer() fyne.WidgetRenderer {
+	r := &colorButtonRenderer{
+		button:     b,
+		background: &canvas.Rectangle{},
+		swatch:     newSwatch(b.color),
+		dim:        &canvas.Rectangle{},
+		text:       canvas.NewText("", theme.ForegroundColor()),
+	}
+	r.Refresh()
+	return r
+}
+
+type colorButtonRenderer struct {
+	button     *ColorButton
+	background *canvas.Rectangle
+	swatch     *swatch
+	// dim covers the swatch while the button is disabled.
+	dim  *canvas.Rectangle
+	text *canvas.Text
+}
+
+func (r *colorButtonRenderer) Layout(size fyne.Size) {
+	pad := theme.InnerPadding()
+	r.background.Resize(size)
+
+	// the swatch fills the button without the label
+	swatchSize := r.swatchSize()
+	if r.text.Text == "" {
+		swatchSize.Width = max(swatchSize.Width, size.Width-pad*2)
+	}
+	swatchPos := fyne.NewPos(pad, (size.Height-swatchSize.Height)/2)
+	r.swatch.Resize(swatchSize)
+	r.swatch.Move(swatchPos)
+	r.dim.Resize(swatchSize)
+	r.dim.Move(swatchPos)
+
+	text := r.text.MinSize()
+	r.text.Resize(text)
+	r.text.Move(fyne.NewPos(pad+swatchSize.Width+theme.Padding(), (size.Height-text.Height)/2))
+}
+
+// swatchSize returns the minimum size of the swatch, which is as high as a line of the label.
+func (r *colorButtonRenderer) swatchSize() fyne.Size {
+	h := max(fyne.MeasureText("#", theme.TextSize(), fyne.TextStyle{}).Height, theme.IconInlineSize())
+	return fyne.NewSize(h*2, h)
+}
+
+func (r *colorButtonRenderer) MinSize() fyne.Size {
+	pad := theme.InnerPadding()
+	size := r.swatchSize()
+	if r.text.Text != "" {
+		size.Width += theme.Padding() + r.text.MinSize().Width
+	}
+	return size.Add(fyne.NewSize(pad*2, pad*2))
+}
+
+func (r *colorButtonRenderer) Refresh() {
+	b := r.button
+	r.background.CornerRadius = theme.InputRadiusSize()
+	r.background.FillColor = r.backgroundColor()
+	r.background.StrokeColor = theme.FocusColor()
+	r.background.StrokeWidth = 0
+	if b.focused && !b.Disabled() {
+		r.background.StrokeWidth = 2
+	}
+	r.swatch.setColor(b.color)
+	r.dim.FillColor = transparent
+	r.text.Color = theme.ForegroundColor()
+	if b.Disabled() {
+		r.dim.FillColor = disabledOverlayColor()
+		r.text.Color = theme.DisabledColor()
+	}
+	r.text.Text = b.label()
+	r.text.TextSize = theme.TextSize()
+	r.Layout(b.Size())
+	r.background.Refresh()
+	r.dim.Refresh()
+	r.text.Refresh()
+}
+
+func (r *colorButtonRenderer) backgroundColor() color.Color {
+	b := r.button
+	switch {
+	case b.Disabled():
+		return theme.DisabledButtonColor()
+	case b.pressed:
+		return flattenColor(theme.PressedColor(), theme.ButtonColor())
+	case b.hovered:
+		return flattenColor(theme.HoverColor(), theme.ButtonColor())
+	default:
+		return theme.ButtonColor()
+	}
+}
+
+func (r *colorButtonRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.background, r.swatch, r.dim, r.text}
+}
+
+func (r *colorButtonRenderer) Destroy() {}
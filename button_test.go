@@ -0,0 +1,105 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/lusingander/colorpicker/csscolor"
+)
+
+func TestColorButton(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 400))
+	original := color.NRGBA{R: 0xff, A: 0xff}
+	b := NewColorButton(w, original)
+	var opener PickerOpenWidget = b
+	w.SetContent(opener)
+	var changed []color.Color
+	b.SetOnChange(func(c color.Color) { changed = append(changed, c) })
+	committed := 0
+	b.addCommitListener(func(_, _ color.Color) { committed++ })
+
+	test.Tap(b)
+	findCompareSwatch(w.Canvas().Overlays().Top()).picker.SetColor(color.Black)
+	if got := b.Color(); !colorEquals(got, color.Black) || len(changed) != 1 {
+		t.Errorf("Color() = %v and changed %v while previewing; want black once", got, changed)
+	}
+	tapButton(t, w.Canvas().Overlays().Top(), "Cancel")
+	if got := b.Color(); !colorEquals(got, original) || committed != 0 {
+		t.Errorf("Color() = %v and committed %d times after Cancel; want %v without commit", got, committed, original)
+	}
+
+	b.TypedKey(&fyne.KeyEvent{Name: fyne.KeySpace})
+	findCompareSwatch(w.Canvas().Overlays().Top()).picker.SetColor(color.Black)
+	tapButton(t, w.Canvas().Overlays().Top(), "OK")
+	if got := b.Color(); !colorEquals(got, color.Black) || committed != 1 {
+		t.Errorf("Color() = %v and committed %d times after OK; want black once", got, committed)
+	}
+}
+
+func TestColorButtonLabel(t *testing.T) {
+	test.NewTempApp(t)
+	b := NewColorButton(test.NewWindow(nil), color.NRGBA{R: 0xff, A: 0xff})
+	r := test.WidgetRenderer(b).(*colorButtonRenderer)
+	if got := r.text.Text; got != "#ff0000" {
+		t.Errorf("label = %q; want #ff0000", got)
+	}
+	b.SetNotation(csscolor.NotationNamed)
+	if got := r.text.Text; got != "red" {
+		t.Errorf("label = %q; want red", got)
+	}
+
+	withLabel := b.MinSize()
+	b.SetLabelVisible(false)
+	if got := r.text.Text; got != "" {
+		t.Errorf("label = %q; want none", got)
+	}
+	if got := b.MinSize(); got.Width >= withLabel.Width || got.Height != withLabel.Height {
+		t.Errorf("MinSize() = %v without label; want narrower than %v", got, withLabel)
+	}
+	// the swatch fills the button without the label
+	b.Resize(fyne.NewSize(100, b.MinSize().Height))
+	if got, want := r.swatch.Size().Width, 100-theme.InnerPadding()*2; got != want {
+		t.Errorf("swatch width = %v; want %v", got, want)
+	}
+}
+
+func TestColorButtonStates(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	b := NewColorButton(w, color.NRGBA{R: 0xff, A: 0xff})
+	r := test.WidgetRenderer(b).(*colorButtonRenderer)
+
+	if got := r.background.FillColor; got != theme.ButtonColor() {
+		t.Errorf("background = %v; want the button color", got)
+	}
+	b.MouseIn(nil)
+	hovered := r.background.FillColor
+	if hovered == theme.ButtonColor() {
+		t.Error("background is not changed by hovering")
+	}
+	b.MouseDown(nil)
+	if got := r.background.FillColor; got == hovered {
+		t.Error("background is not changed by pressing")
+	}
+	b.MouseUp(nil)
+	b.MouseOut()
+
+	b.Disable()
+	if got := r.background.FillColor; got != theme.DisabledButtonColor() {
+		t.Errorf("background = %v while disabled; want the disabled button color", got)
+	}
+	if got := r.text.Color; got != theme.DisabledColor() {
+		t.Errorf("label color = %v while disabled; want the disabled color", got)
+	}
+	test.Tap(b)
+	if w.Canvas().Overlays().Top() != nil {
+		t.Error("the dialog is opened while disabled")
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"image/color"
 
 	"fyne.io/fyne/v2"
@@ -12,6 +11,7 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
 	"github.com/lusingander/colorpicker"
+	"github.com/lusingander/colorpicker/csscolor"
 )
 
 var (
@@ -27,21 +27,14 @@ func main() {
 }
 
 func createContainer(w fyne.Window) fyne.CanvasObject {
-	var currentSimple color.Color
-	currentSimple = defaultColor
-
 	simpleDisplayColor := newSimpleDisplayColor()
 	picker := colorpicker.New(200, colorpicker.StyleHue)
-	picker.SetOnChanged(func(c color.Color) {
-		currentSimple = c
-		simpleDisplayColor.setColor(currentSimple)
-	})
+	picker.SetOnChanged(simpleDisplayColor.setColor)
+	picker.SetColor(defaultColor)
 	content := container.NewWithoutLayout(picker)
 	button := widget.NewButton("Open color picker", func() {
-		picker.SetColor(currentSimple)
 		dialog.ShowCustom("Select color", "OK", content, w)
 	})
-	simpleDisplayColor.setColor(currentSimple)
 
 	tappableDisplayColor := newTappableDisplayColor(w)
 	tappableDisplayColor.setColor(defaultColor)
@@ -91,7 +84,7 @@ func newSimpleDisplayColor() *simpleDisplayColor {
 }
 
 func (c *simpleDisplayColor) setColor(clr color.Color) {
-	c.label.SetText(hexColorString(clr))
+	c.label.SetText(csscolor.Format(clr, csscolor.NotationHex))
 	c.rect.FillColor = clr
 	c.rect.Refresh()
 }
@@ -113,10 +106,5 @@ func newTappableDisplayColor(w fyne.Window) *tappableDisplayColor {
 }
 
 func (c *tappableDisplayColor) setColor(clr color.Color) {
-	c.label.SetText(hexColorString(clr))
-}
-
-func hexColorString(c color.Color) string {
-	rgba, _ := c.(color.NRGBA)
-	return fmt.Sprintf("#%.2X%.2X%.2X%.2X", rgba.R, rgba.G, rgba.B, rgba.A)
+	c.label.SetText(csscolor.Format(clr, csscolor.NotationHex))
 }
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"image/color"
 
 	"fyne.io/fyne/v2"
@@ -12,6 +11,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/lusingander/colorpicker"
+	"github.com/lusingander/colorpicker/csscolor"
 )
 
 var (
@@ -34,6 +34,11 @@ func main() {
 			createPickerContainer(200, colorpicker.StyleValue),
 			createPickerContainer(200, colorpicker.StyleSaturation),
 		),
+		container.New(
+			layout.NewHBoxLayout(),
+			createPickerContainer(200, colorpicker.StyleLightness),
+			createPickerContainer(200, colorpicker.StyleOKLCH),
+		),
 	))
 
 	w.ShowAndRun()
@@ -49,46 +54,34 @@ func createPickerContainer(height float32, style colorpicker.PickerStyle) *fyne.
 	})
 	picker.SetColor(defaultColor)
 
+	// Create entry to display and edit the color
+	entry := colorpicker.NewColorEntry(picker, csscolor.NotationHex)
+
 	return container.New(
 		layout.NewVBoxLayout(),
 		picker, // layout
-		container.New(
-			layout.NewHBoxLayout(),
-			layout.NewSpacer(),
-			displayColor.label,
-			displayColor.rect,
-			layout.NewSpacer(),
-		),
+		container.NewBorder(nil, nil, nil, displayColor.rect, entry),
 		widget.NewLabel(styleName(style)),
 	)
 }
 
 type displayColor struct {
-	label *widget.Label
-	rect  *canvas.Rectangle
+	rect *canvas.Rectangle
 }
 
 func newDisplayColor() *displayColor {
-	selectColorCode := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
 	selectColorRect := &canvas.Rectangle{FillColor: color.NRGBA{0, 0, 0, 0}}
 	selectColorRect.SetMinSize(fyne.NewSize(30, 20))
 	return &displayColor{
-		label: selectColorCode,
-		rect:  selectColorRect,
+		rect: selectColorRect,
 	}
 }
 
 func (c *displayColor) setColor(clr color.Color) {
-	c.label.SetText(hexColorString(clr))
 	c.rect.FillColor = clr
 	c.rect.Refresh()
 }
 
-func hexColorString(c color.Color) string {
-	rgba, _ := c.(color.NRGBA)
-	return fmt.Sprintf("#%.2X%.2X%.2X%.2X", rgba.R, rgba.G, rgba.B, rgba.A)
-}
-
 func styleName(s colorpicker.PickerStyle) string {
 	switch s {
 	case colorpicker.StyleHueCircle:
@@ -97,6 +90,10 @@ func styleName(s colorpicker.PickerStyle) string {
 		return "StyleValue"
 	case colorpicker.StyleSaturation:
 		return "StyleSaturation"
+	case colorpicker.StyleLightness:
+		return "StyleLightness"
+	case colorpicker.StyleOKLCH:
+		return "StyleOKLCH"
 	default:
 		return "StyleHue"
 	}
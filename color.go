@@ -89,6 +89,49 @@ func fromColor(c color.Color) (h, s, v, a float64) {
 		return
 	}
 	s = d / max
+	h = calcHue(r, g, b, max, d)
+	return
+}
+
+func fromHSL(h, s, l float64) color.NRGBA {
+	f := func(n float64) float64 {
+		k := math.Mod(n+h*12, 12)
+		a := s * math.Min(l, 1-l)
+		return l - a*math.Max(-1, math.Min(k-3, math.Min(9-k, 1)))
+	}
+	return color.NRGBA{
+		R: roundUint8(f(0) * 255),
+		G: roundUint8(f(8) * 255),
+		B: roundUint8(f(4) * 255),
+		A: 0xff,
+	}
+}
+
+func fromHSLA(h, s, l, a float64) color.NRGBA {
+	rgba := fromHSL(h, s, l)
+	rgba.A = roundUint8(a * 255)
+	return rgba
+}
+
+func toHSL(c color.Color) (h, s, l, a float64) {
+	r, g, b, a := toFloatRGBA(c)
+	min := math.Min(r, math.Min(g, b))
+	max := math.Max(r, math.Max(g, b))
+	l = (max + min) / 2
+
+	d := max - min
+	if d == 0 {
+		h = 0
+		s = 0
+		return
+	}
+	s = d / (1 - math.Abs(max+min-1))
+	h = calcHue(r, g, b, max, d)
+	return
+}
+
+func calcHue(r, g, b, max, d float64) float64 {
+	var h float64
 	if r == max {
 		h = (1. / 6.) * ((g - b) / d)
 	} else if g == max {
@@ -101,20 +144,59 @@ func fromColor(c color.Color) (h, s, v, a float64) {
 	} else if h > 1 {
 		h--
 	}
-	return
+	return h
+}
+
+// hsvToHSL converts HSV saturation and value to HSL saturation and lightness (hue is common).
+func hsvToHSL(s, v float64) (float64, float64) {
+	l := v * (1 - s/2)
+	if l == 0 {
+		// limit as value approaches 0, so that saturation survives black
+		return s / (2 - s), l
+	}
+	if l == 1 {
+		return 0, l
+	}
+	return (v - l) / math.Min(l, 1-l), l
+}
+
+// hslToHSV converts HSL saturation and lightness to HSV saturation and value (hue is common).
+func hslToHSV(s, l float64) (float64, float64) {
+	v := l + s*math.Min(l, 1-l)
+	if v == 0 {
+		// limit as lightness approaches 0, so that saturation survives black
+		return 2 * s / (1 + s), v
+	}
+	return 2 * (1 - l/v), v
 }
 
 func toFloatRGBA(c color.Color) (float64, float64, float64, float64) {
-	rgba, _ := c.(color.NRGBA)
-	max := 255.
+	if rgba, ok := c.(color.NRGBA); ok {
+		max := 255.
+		return float64(rgba.R) / max, float64(rgba.G) / max, float64(rgba.B) / max, float64(rgba.A) / max
+	}
+	// NRGBA64Model un-premultiplies alpha of the values returned by RGBA()
+	rgba := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+	max := 65535.
 	return float64(rgba.R) / max, float64(rgba.G) / max, float64(rgba.B) / max, float64(rgba.A) / max
 }
 
+// flattenColor returns c composited over the opaque background, which is fully opaque.
+func flattenColor(c, background color.Color) color.NRGBA {
+	r, g, b, a := toFloatRGBA(c)
+	br, bg, bb, _ := toFloatRGBA(background)
+	return fromFloatNRGBA(r*a+br*(1-a), g*a+bg*(1-a), b*a+bb*(1-a), 1)
+}
+
 func roundUint8(v float64) uint8 {
 	return uint8(math.Round(v))
 }
 
-func fromFloatNRGBA(r, g, b, a float64) color.Color {
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func fromFloatNRGBA(r, g, b, a float64) color.NRGBA {
 	return color.NRGBA{
 		R: roundUint8(r * 255),
 		G: roundUint8(g * 255),
@@ -101,6 +101,192 @@ func TestFromColorAndFromHSV(t *testing.T) {
 	}
 }
 
+func TestFromHSL(t *testing.T) {
+	tests := []struct {
+		h, s, l float64
+		want    color.Color
+	}{
+		{0 / 360., 1., 0.5, color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+		{30 / 360., 1., 0.5, color.NRGBA{0xff, 0x80, 0x00, 0xff}},
+		{60 / 360., 1., 0.5, color.NRGBA{0xff, 0xff, 0x00, 0xff}},
+		{90 / 360., 1., 0.5, color.NRGBA{0x80, 0xff, 0x00, 0xff}},
+		{120 / 360., 1., 0.5, color.NRGBA{0x00, 0xff, 0x00, 0xff}},
+		{150 / 360., 1., 0.5, color.NRGBA{0x00, 0xff, 0x80, 0xff}},
+		{180 / 360., 1., 0.5, color.NRGBA{0x00, 0xff, 0xff, 0xff}},
+		{210 / 360., 1., 0.5, color.NRGBA{0x00, 0x80, 0xff, 0xff}},
+		{240 / 360., 1., 0.5, color.NRGBA{0x00, 0x00, 0xff, 0xff}},
+		{270 / 360., 1., 0.5, color.NRGBA{0x80, 0x00, 0xff, 0xff}},
+		{300 / 360., 1., 0.5, color.NRGBA{0xff, 0x00, 0xff, 0xff}},
+		{330 / 360., 1., 0.5, color.NRGBA{0xff, 0x00, 0x80, 0xff}},
+		{360 / 360., 1., 0.5, color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+		{0 / 360., 1., 0., color.NRGBA{0x00, 0x00, 0x00, 0xff}},
+		{0 / 360., 1., 0.1, color.NRGBA{0x33, 0x00, 0x00, 0xff}},
+		{0 / 360., 1., 0.3, color.NRGBA{0x99, 0x00, 0x00, 0xff}},
+		{0 / 360., 1., 0.7, color.NRGBA{0xff, 0x66, 0x66, 0xff}},
+		{0 / 360., 1., 1., color.NRGBA{0xff, 0xff, 0xff, 0xff}},
+		{180 / 360., 0., 0.5, color.NRGBA{0x80, 0x80, 0x80, 0xff}},
+		{180 / 360., 0.2, 0.5, color.NRGBA{0x66, 0x99, 0x99, 0xff}},
+		{180 / 360., 0.6, 0.5, color.NRGBA{0x33, 0xcc, 0xcc, 0xff}},
+	}
+	for _, test := range tests {
+		got := fromHSL(test.h, test.s, test.l)
+		if got != test.want {
+			t.Errorf("fromHSL(%f, %f, %f) = %v; want %v",
+				test.h, test.s, test.l, got, test.want)
+		}
+	}
+}
+
+func TestToHSL(t *testing.T) {
+	tests := []struct {
+		c       color.Color
+		h, s, l float64
+	}{
+		{color.NRGBA{0xff, 0x00, 0x00, 0xff}, 0 / 360., 1., 0.5},
+		{color.NRGBA{0x00, 0xff, 0x00, 0xff}, 120 / 360., 1., 0.5},
+		{color.NRGBA{0x00, 0x00, 0xff, 0xff}, 240 / 360., 1., 0.5},
+		{color.NRGBA{0x33, 0x00, 0x00, 0xff}, 0 / 360., 1., 0.1},
+		{color.NRGBA{0xff, 0x66, 0x66, 0xff}, 0 / 360., 1., 0.7},
+		{color.NRGBA{0x66, 0x99, 0x99, 0xff}, 180 / 360., 0.2, 0.5},
+		{color.NRGBA{0x33, 0xcc, 0xcc, 0xff}, 180 / 360., 0.6, 0.5},
+		{color.NRGBA{0x00, 0x00, 0x00, 0xff}, 0., 0., 0.},
+		{color.NRGBA{0xff, 0xff, 0xff, 0xff}, 0., 0., 1.},
+	}
+	for _, test := range tests {
+		h, s, l, _ := toHSL(test.c)
+		if notEquals(test.h, h) || notEquals(test.s, s) || notEquals(test.l, l) {
+			t.Errorf("toHSL(%v) = %f, %f, %f; want %f, %f, %f",
+				test.c, h, s, l, test.h, test.s, test.l)
+		}
+	}
+}
+
+func TestToHSLAndFromHSL(t *testing.T) {
+	for r := 0; r <= 255; r++ {
+		for g := 0; g <= 255; g++ {
+			for b := 0; b <= 255; b++ {
+				want := color.NRGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 0xff}
+				h, s, l, _ := toHSL(want)
+				got := fromHSL(h, s, l)
+				if want != got {
+					t.Errorf("fromHSL(toHSL(%v)) = %v", want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestHSVToHSLAndHSLToHSV(t *testing.T) {
+	for r := 0; r <= 255; r += 5 {
+		for g := 0; g <= 255; g += 5 {
+			for b := 0; b <= 255; b += 5 {
+				c := color.NRGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 0xff}
+				_, s, v, _ := fromColor(c)
+				_, wantS, wantL, _ := toHSL(c)
+				gotS, gotL := hsvToHSL(s, v)
+				if notEquals(wantS, gotS) || notEquals(wantL, gotL) {
+					t.Errorf("hsvToHSL(%f, %f) = %f, %f; want %f, %f", s, v, gotS, gotL, wantS, wantL)
+				}
+				gotS, gotV := hslToHSV(wantS, wantL)
+				if notEquals(s, gotS) || notEquals(v, gotV) {
+					t.Errorf("hslToHSV(%f, %f) = %f, %f; want %f, %f", wantS, wantL, gotS, gotV, s, v)
+				}
+			}
+		}
+	}
+}
+
+type premultipliedColor struct {
+	r, g, b, a uint32
+}
+
+func (c premultipliedColor) RGBA() (uint32, uint32, uint32, uint32) {
+	return c.r, c.g, c.b, c.a
+}
+
+type namedColor struct {
+	color.NRGBA
+	name string
+}
+
+func TestToFloatRGBA(t *testing.T) {
+	tests := []struct {
+		c          color.Color
+		r, g, b, a float64
+	}{
+		{color.NRGBA{0xff, 0x80, 0x00, 0x80}, 1., 0x80 / 255., 0., 0x80 / 255.},
+		{color.NRGBA64{0xffff, 0x8000, 0x0000, 0x8000}, 1., 0x8000 / 65535., 0., 0x8000 / 65535.},
+		{color.RGBA{0xff, 0x80, 0x00, 0xff}, 1., 0x80 / 255., 0., 1.},
+		{color.RGBA{0x80, 0x40, 0x00, 0x80}, 1., 0.5, 0., 0x80 / 255.},
+		{color.RGBA{0x00, 0x00, 0x00, 0x00}, 0., 0., 0., 0.},
+		{color.RGBA64{0xffff, 0x8000, 0x0000, 0xffff}, 1., 0x8000 / 65535., 0., 1.},
+		{color.RGBA64{0x8000, 0x4000, 0x0000, 0x8000}, 1., 0.5, 0., 0x8000 / 65535.},
+		{color.Gray{0x80}, 0x80 / 255., 0x80 / 255., 0x80 / 255., 1.},
+		{color.Gray16{0x8000}, 0x8000 / 65535., 0x8000 / 65535., 0x8000 / 65535., 1.},
+		{color.Alpha{0x80}, 1., 1., 1., 0x80 / 255.},
+		{color.Alpha16{0x8000}, 1., 1., 1., 0x8000 / 65535.},
+		{color.Black, 0., 0., 0., 1.},
+		{color.White, 1., 1., 1., 1.},
+		{color.Transparent, 0., 0., 0., 0.},
+		{color.Opaque, 1., 1., 1., 1.},
+		{color.CMYK{0x00, 0xff, 0xff, 0x00}, 1., 0., 0., 1.},
+		{color.YCbCr{0xff, 0x80, 0x80}, 1., 1., 1., 1.},
+		{color.NYCbCrA{color.YCbCr{0xff, 0x80, 0x80}, 0x80}, 1., 1., 1., 0x80 / 255.},
+		{premultipliedColor{0x8000, 0x4000, 0x0000, 0x8000}, 1., 0.5, 0., 0x8000 / 65535.},
+		{namedColor{color.NRGBA{0x00, 0x80, 0xff, 0x80}, "accent"}, 0., 0x80 / 255., 1., 0x80 / 255.},
+	}
+	for _, test := range tests {
+		r, g, b, a := toFloatRGBA(test.c)
+		if notEquals(test.r, r) || notEquals(test.g, g) || notEquals(test.b, b) || notEquals(test.a, a) {
+			t.Errorf("toFloatRGBA(%#v) = %f, %f, %f, %f; want %f, %f, %f, %f",
+				test.c, r, g, b, a, test.r, test.g, test.b, test.a)
+		}
+	}
+}
+
+func TestFromColorWithColorModels(t *testing.T) {
+	tests := []struct {
+		c          color.Color
+		h, s, v, a float64
+	}{
+		{color.RGBA{0xff, 0x00, 0x00, 0xff}, 0 / 360., 1., 1., 1.},
+		{color.RGBA{0x00, 0x80, 0x00, 0x80}, 120 / 360., 1., 1., 0x80 / 255.},
+		{color.RGBA64{0x0000, 0x0000, 0x8000, 0x8000}, 240 / 360., 1., 1., 0x8000 / 65535.},
+		{color.NRGBA64{0x0000, 0xffff, 0xffff, 0xffff}, 180 / 360., 1., 1., 1.},
+		{color.Gray{0x33}, 0., 0., 0.2, 1.},
+		{color.Gray16{0xcccc}, 0., 0., 0.8, 1.},
+		{color.Alpha{0x80}, 0., 0., 1., 0x80 / 255.},
+		{color.CMYK{0x00, 0x00, 0xff, 0x00}, 60 / 360., 1., 1., 1.},
+		{premultipliedColor{0x0000, 0x4000, 0x8000, 0x8000}, 210 / 360., 1., 1., 0x8000 / 65535.},
+		{namedColor{color.NRGBA{0xff, 0x00, 0xff, 0xff}, "magenta"}, 300 / 360., 1., 1., 1.},
+	}
+	for _, test := range tests {
+		h, s, v, a := fromColor(test.c)
+		if notEquals(test.h, h) || notEquals(test.s, s) || notEquals(test.v, v) || notEquals(test.a, a) {
+			t.Errorf("fromColor(%#v) = %f, %f, %f, %f; want %f, %f, %f, %f",
+				test.c, h, s, v, a, test.h, test.s, test.v, test.a)
+		}
+	}
+}
+
+func TestFlattenColor(t *testing.T) {
+	tests := []struct {
+		c, background color.Color
+		want          color.NRGBA
+	}{
+		{color.NRGBA{255, 0, 0, 255}, color.White, color.NRGBA{255, 0, 0, 255}},
+		{color.NRGBA{255, 0, 0, 0}, color.White, color.NRGBA{255, 255, 255, 255}},
+		{color.NRGBA{255, 0, 0, 128}, color.White, color.NRGBA{255, 127, 127, 255}},
+		{color.NRGBA{255, 0, 0, 128}, color.Black, color.NRGBA{128, 0, 0, 255}},
+		{color.RGBA{0, 0, 100, 128}, color.NRGBA{0, 100, 0, 255}, color.NRGBA{0, 50, 100, 255}},
+	}
+	for _, test := range tests {
+		if got := flattenColor(test.c, test.background); got != test.want {
+			t.Errorf("flattenColor(%v, %v) = %v; want %v", test.c, test.background, got, test.want)
+		}
+	}
+}
+
 func notEquals(f1, f2 float64) bool {
 	return math.Abs(f1-f2) > floatThreshold
 }
@@ -18,26 +18,64 @@ const (
 	StyleValue
 	// StyleSaturation is style to display hue-value area and vertical saturation bar.
 	StyleSaturation
+	// StyleLightness is style to display HSL saturation-lightness area and vertical hue bar.
+	StyleLightness
+	// StyleOKLCH is style to display OKLCH chroma-lightness area and vertical hue bar.
+	StyleOKLCH
 )
 
 // ColorPicker represents color picker component.
 type ColorPicker interface {
 	fyne.CanvasObject
+	fyne.Disableable
 
 	SetColor(color.Color)
 	SetOnChanged(func(color.Color))
+
+	// Color returns the currently selected color.
+	Color() color.Color
+	// HSVA returns the currently selected color as hue, saturation, value and alpha, each in the range [0, 1].
+	HSVA() (h, s, v, a float64)
+	// SetHSVA sets the selected color by hue, saturation, value and alpha, each in the range [0, 1].
+	SetHSVA(h, s, v, a float64)
+	// SetScrollStep sets the amount the bars move by one notch of scrolling, relative to their range.
+	SetScrollStep(step float64)
+
+	// SetOnChangeStarted sets the function called with the current color when a tap, drag, key press or scroll on the picker starts.
+	SetOnChangeStarted(func(color.Color))
+	// SetOnChangeEnded sets the function called with the resulting color when the tap, drag, key press or scroll ends.
+	SetOnChangeEnded(func(color.Color))
+	// SetOnCommitted sets the function called once with the resulting color when a tap, drag, key press or scroll
+	// has changed the color, unlike the function set by SetOnChanged, which is called on every move of a drag.
+	SetOnCommitted(func(color.Color))
 }
 
 // New returns color picker container.
+// The size is the minimum height of the picker, which grows keeping its aspect ratio to fit the size allocated by its container.
 func New(size float32, style PickerStyle) ColorPicker {
+	return NewWithOptions(style, WithSize(size))
+}
+
+// NewWithOptions returns color picker container configured by opts.
+func NewWithOptions(style PickerStyle, opts ...Option) ColorPicker {
+	o := newOptions(opts...)
+	var picker ColorPicker
 	switch style {
 	case StyleHueCircle:
-		return newCircleHueColorPicker(size)
+		picker = newCircleHueColorPicker(o)
 	case StyleValue:
-		return newValueColorPicker(size)
+		picker = newValueColorPicker(o)
 	case StyleSaturation:
-		return newSaturationColorPicker(size)
+		picker = newSaturationColorPicker(o)
+	case StyleLightness:
+		picker = newLightnessColorPicker(o)
+	case StyleOKLCH:
+		picker = newOKLCHColorPicker(o)
 	default:
-		return newDefaultHueColorPicker(size)
+		picker = newDefaultHueColorPicker(o)
+	}
+	if o.initialColor != nil {
+		picker.SetColor(o.initialColor)
 	}
+	return picker
 }
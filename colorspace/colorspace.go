@@ -0,0 +1,255 @@
+// Package colorspace provides conversions between sRGB, linear RGB, CIE XYZ, CIELAB and LCh(ab),
+// and color difference metrics (ΔE76, ΔE94 and CIEDE2000).
+package colorspace
+
+import (
+	"image/color"
+	"math"
+)
+
+// XYZ represents a color in the CIE 1931 XYZ color space, scaled so that Y = 1 for the reference white.
+type XYZ struct {
+	X, Y, Z float64
+}
+
+// Lab represents a color in the CIELAB color space. L is in the range [0, 100].
+type Lab struct {
+	L, A, B float64
+}
+
+// LCh represents a color in the LCh(ab) color space, the cylindrical form of CIELAB. H is in degrees [0, 360).
+type LCh struct {
+	L, C, H float64
+}
+
+var (
+	// D65 is the CIE standard illuminant D65 white point, the reference white of sRGB.
+	D65 = XYZ{0.3127 / 0.3290, 1, (1 - 0.3127 - 0.3290) / 0.3290}
+	// D50 is the CIE standard illuminant D50 white point, the reference white of ICC profiles and CSS lab().
+	D50 = XYZ{0.3457 / 0.3585, 1, (1 - 0.3457 - 0.3585) / 0.3585}
+)
+
+const (
+	labEpsilon = 216. / 24389.
+	labKappa   = 24389. / 27.
+)
+
+var (
+	linearSRGBToXYZMatrix = matrix3{
+		{506752. / 1228815., 87881. / 245763., 12673. / 70218.},
+		{87098. / 409605., 175762. / 245763., 12673. / 175545.},
+		{7918. / 409605., 87881. / 737289., 1001167. / 1053270.},
+	}
+	xyzToLinearSRGBMatrix = matrix3{
+		{12831. / 3959., -329. / 214., -1974. / 3959.},
+		{-851781. / 878810., 1648619. / 878810., 36519. / 878810.},
+		{705. / 12673., -2585. / 12673., 705. / 667.},
+	}
+	linearDisplayP3ToXYZMatrix = matrix3{
+		{608311. / 1250200., 189793. / 714400., 198249. / 1000160.},
+		{35783. / 156275., 247089. / 357200., 198249. / 2500400.},
+		{0. / 1., 32229. / 714400., 5220557. / 5000800.},
+	}
+	xyzToLinearDisplayP3Matrix = matrix3{
+		{446124. / 178915., -333277. / 357830., -72051. / 178915.},
+		{-14852. / 17905., 63121. / 35810., 423. / 17905.},
+		{11844. / 330415., -50337. / 660830., 316169. / 330415.},
+	}
+	bradfordMatrix = matrix3{
+		{0.8951, 0.2664, -0.1614},
+		{-0.7502, 1.7135, 0.0367},
+		{0.0389, -0.0685, 1.0296},
+	}
+	bradfordInverseMatrix = bradfordMatrix.inverse()
+)
+
+// SRGBToLinear converts a gamma encoded sRGB component to linear light.
+// Values outside [0, 1] are extended symmetrically.
+func SRGBToLinear(c float64) float64 {
+	abs := math.Abs(c)
+	if abs <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Copysign(math.Pow((abs+0.055)/1.055, 2.4), c)
+}
+
+// LinearToSRGB converts a linear light component to gamma encoded sRGB.
+// Values outside [0, 1] are extended symmetrically.
+func LinearToSRGB(c float64) float64 {
+	abs := math.Abs(c)
+	if abs <= 0.0031308 {
+		return c * 12.92
+	}
+	return math.Copysign(1.055*math.Pow(abs, 1/2.4)-0.055, c)
+}
+
+// LinearSRGBToXYZ converts linear sRGB to XYZ relative to D65.
+func LinearSRGBToXYZ(r, g, b float64) XYZ {
+	x, y, z := linearSRGBToXYZMatrix.apply(r, g, b)
+	return XYZ{x, y, z}
+}
+
+// XYZToLinearSRGB converts XYZ relative to D65 to linear sRGB. The result is not clipped to [0, 1].
+func XYZToLinearSRGB(c XYZ) (r, g, b float64) {
+	return xyzToLinearSRGBMatrix.apply(c.X, c.Y, c.Z)
+}
+
+// LinearDisplayP3ToXYZ converts linear Display P3 to XYZ relative to D65.
+// Display P3 uses the same transfer function as sRGB.
+func LinearDisplayP3ToXYZ(r, g, b float64) XYZ {
+	x, y, z := linearDisplayP3ToXYZMatrix.apply(r, g, b)
+	return XYZ{x, y, z}
+}
+
+// XYZToLinearDisplayP3 converts XYZ relative to D65 to linear Display P3. The result is not clipped to [0, 1].
+func XYZToLinearDisplayP3(c XYZ) (r, g, b float64) {
+	return xyzToLinearDisplayP3Matrix.apply(c.X, c.Y, c.Z)
+}
+
+// Adapt converts XYZ relative to the white point from to XYZ relative to the white point to,
+// using the Bradford chromatic adaptation transform.
+func Adapt(c XYZ, from, to XYZ) XYZ {
+	if from == to {
+		return c
+	}
+	fr, fg, fb := bradfordMatrix.apply(from.X, from.Y, from.Z)
+	tr, tg, tb := bradfordMatrix.apply(to.X, to.Y, to.Z)
+	r, g, b := bradfordMatrix.apply(c.X, c.Y, c.Z)
+	x, y, z := bradfordInverseMatrix.apply(r*tr/fr, g*tg/fg, b*tb/fb)
+	return XYZ{x, y, z}
+}
+
+// XYZToLab converts XYZ to CIELAB using the given reference white.
+func XYZToLab(c XYZ, white XYZ) Lab {
+	f := func(t float64) float64 {
+		if t > labEpsilon {
+			return math.Cbrt(t)
+		}
+		return (labKappa*t + 16) / 116
+	}
+	fx := f(c.X / white.X)
+	fy := f(c.Y / white.Y)
+	fz := f(c.Z / white.Z)
+	return Lab{
+		L: 116*fy - 16,
+		A: 500 * (fx - fy),
+		B: 200 * (fy - fz),
+	}
+}
+
+// LabToXYZ converts CIELAB to XYZ using the given reference white.
+func LabToXYZ(c Lab, white XYZ) XYZ {
+	fy := (c.L + 16) / 116
+	fx := c.A/500 + fy
+	fz := fy - c.B/200
+	f := func(t float64) float64 {
+		if t3 := t * t * t; t3 > labEpsilon {
+			return t3
+		}
+		return (116*t - 16) / labKappa
+	}
+	y := c.L / labKappa
+	if c.L > labKappa*labEpsilon {
+		y = fy * fy * fy
+	}
+	return XYZ{
+		X: f(fx) * white.X,
+		Y: y * white.Y,
+		Z: f(fz) * white.Z,
+	}
+}
+
+// LabToLCh converts CIELAB to LCh(ab).
+func LabToLCh(c Lab) LCh {
+	h := math.Atan2(c.B, c.A) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return LCh{
+		L: c.L,
+		C: math.Hypot(c.A, c.B),
+		H: h,
+	}
+}
+
+// LChToLab converts LCh(ab) to CIELAB.
+func LChToLab(c LCh) Lab {
+	rad := c.H * math.Pi / 180
+	return Lab{
+		L: c.L,
+		A: c.C * math.Cos(rad),
+		B: c.C * math.Sin(rad),
+	}
+}
+
+// ColorToXYZ converts c to XYZ relative to D65, treating it as sRGB.
+func ColorToXYZ(c color.Color) XYZ {
+	r, g, b, _ := toFloatRGBA(c)
+	return LinearSRGBToXYZ(SRGBToLinear(r), SRGBToLinear(g), SRGBToLinear(b))
+}
+
+// XYZToColor converts XYZ relative to D65 to sRGB, clipping out of gamut components. Alpha is set to opaque.
+func XYZToColor(c XYZ) color.NRGBA {
+	r, g, b := XYZToLinearSRGB(c)
+	return color.NRGBA{
+		R: toUint8(LinearToSRGB(r)),
+		G: toUint8(LinearToSRGB(g)),
+		B: toUint8(LinearToSRGB(b)),
+		A: 0xff,
+	}
+}
+
+// ColorToLab converts c to CIELAB using the given reference white, treating it as sRGB.
+func ColorToLab(c color.Color, white XYZ) Lab {
+	return XYZToLab(Adapt(ColorToXYZ(c), D65, white), white)
+}
+
+// LabToColor converts CIELAB with the given reference white to sRGB, clipping out of gamut components.
+func LabToColor(c Lab, white XYZ) color.NRGBA {
+	return XYZToColor(Adapt(LabToXYZ(c, white), white, D65))
+}
+
+func toFloatRGBA(c color.Color) (float64, float64, float64, float64) {
+	if rgba, ok := c.(color.NRGBA); ok {
+		max := 255.
+		return float64(rgba.R) / max, float64(rgba.G) / max, float64(rgba.B) / max, float64(rgba.A) / max
+	}
+	rgba := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+	max := 65535.
+	return float64(rgba.R) / max, float64(rgba.G) / max, float64(rgba.B) / max, float64(rgba.A) / max
+}
+
+func toUint8(v float64) uint8 {
+	return uint8(math.Round(clamp01(v) * 255))
+}
+
+type matrix3 [3][3]float64
+
+func (m matrix3) apply(x, y, z float64) (float64, float64, float64) {
+	return m[0][0]*x + m[0][1]*y + m[0][2]*z,
+		m[1][0]*x + m[1][1]*y + m[1][2]*z,
+		m[2][0]*x + m[2][1]*y + m[2][2]*z
+}
+
+func (m matrix3) inverse() matrix3 {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	return matrix3{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det,
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package colorspace
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+const (
+	floatThreshold = 0.01
+)
+
+func TestColorToLab(t *testing.T) {
+	tests := []struct {
+		c     color.Color
+		white XYZ
+		want  Lab
+	}{
+		{color.NRGBA{0xff, 0xff, 0xff, 0xff}, D65, Lab{100, 0, 0}},
+		{color.NRGBA{0xff, 0xff, 0xff, 0xff}, D50, Lab{100, 0, 0}},
+		{color.NRGBA{0x00, 0x00, 0x00, 0xff}, D50, Lab{0, 0, 0}},
+		{color.NRGBA{0xff, 0x00, 0x00, 0xff}, D65, Lab{53.2408, 80.0925, 67.2032}},
+		{color.NRGBA{0x00, 0xff, 0x00, 0xff}, D65, Lab{87.7347, -86.1827, 83.1793}},
+		{color.NRGBA{0x00, 0x00, 0xff, 0xff}, D65, Lab{32.2970, 79.1875, -107.8602}},
+		{color.NRGBA{0xff, 0x00, 0x00, 0xff}, D50, Lab{54.2905, 80.8049, 69.8910}},
+		{color.NRGBA{0x80, 0x80, 0x80, 0xff}, D50, Lab{53.5850, 0, 0}},
+	}
+	for _, test := range tests {
+		got := ColorToLab(test.c, test.white)
+		if notEqualsLab(got, test.want) {
+			t.Errorf("ColorToLab(%v, %v) = %v; want %v", test.c, test.white, got, test.want)
+		}
+	}
+}
+
+func TestColorToLabAndLabToColor(t *testing.T) {
+	for _, white := range []XYZ{D65, D50} {
+		for r := 0; r <= 255; r += 5 {
+			for g := 0; g <= 255; g += 5 {
+				for b := 0; b <= 255; b += 5 {
+					want := color.NRGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 0xff}
+					got := LabToColor(ColorToLab(want, white), white)
+					if want != got {
+						t.Errorf("LabToColor(ColorToLab(%v, %v)) = %v", want, white, got)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLabToLCh(t *testing.T) {
+	tests := []struct {
+		lab Lab
+		lch LCh
+	}{
+		{Lab{50, 10, 0}, LCh{50, 10, 0}},
+		{Lab{50, 0, 10}, LCh{50, 10, 90}},
+		{Lab{50, -10, 0}, LCh{50, 10, 180}},
+		{Lab{50, 0, -10}, LCh{50, 10, 270}},
+		{Lab{53.2408, 80.0925, 67.2032}, LCh{53.2408, 104.5518, 39.9990}},
+	}
+	for _, test := range tests {
+		got := LabToLCh(test.lab)
+		if math.Abs(got.L-test.lch.L) > floatThreshold || math.Abs(got.C-test.lch.C) > floatThreshold || math.Abs(got.H-test.lch.H) > floatThreshold {
+			t.Errorf("LabToLCh(%v) = %v; want %v", test.lab, got, test.lch)
+		}
+		if back := LChToLab(got); notEqualsLab(back, test.lab) {
+			t.Errorf("LChToLab(%v) = %v; want %v", got, back, test.lab)
+		}
+	}
+}
+
+func TestAdapt(t *testing.T) {
+	if got := Adapt(D65, D65, D50); notEqualsXYZ(got, D50) {
+		t.Errorf("Adapt(D65, D65, D50) = %v; want %v", got, D50)
+	}
+	if got := Adapt(D50, D50, D65); notEqualsXYZ(got, D65) {
+		t.Errorf("Adapt(D50, D50, D65) = %v; want %v", got, D65)
+	}
+	c := XYZ{0.4, 0.3, 0.2}
+	if got := Adapt(Adapt(c, D65, D50), D50, D65); notEqualsXYZ(got, c) {
+		t.Errorf("Adapt(Adapt(%v, D65, D50), D50, D65) = %v", c, got)
+	}
+}
+
+func notEqualsLab(c1, c2 Lab) bool {
+	return math.Abs(c1.L-c2.L) > floatThreshold || math.Abs(c1.A-c2.A) > floatThreshold || math.Abs(c1.B-c2.B) > floatThreshold
+}
+
+func notEqualsXYZ(c1, c2 XYZ) bool {
+	const threshold = 0.000001
+	return math.Abs(c1.X-c2.X) > threshold || math.Abs(c1.Y-c2.Y) > threshold || math.Abs(c1.Z-c2.Z) > threshold
+}
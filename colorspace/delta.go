@@ -0,0 +1,122 @@
+package colorspace
+
+import (
+	"image/color"
+	"math"
+)
+
+// DeltaE76 returns the CIE 1976 color difference, the euclidean distance in CIELAB.
+func DeltaE76(c1, c2 Lab) float64 {
+	return math.Sqrt(square(c1.L-c2.L) + square(c1.A-c2.A) + square(c1.B-c2.B))
+}
+
+// DeltaE94 returns the CIE 1994 color difference with the graphic arts weighting factors.
+// It is not symmetric: c1 is the reference color and c2 is the sample.
+func DeltaE94(c1, c2 Lab) float64 {
+	const (
+		kL = 1.
+		kC = 1.
+		kH = 1.
+		k1 = 0.045
+		k2 = 0.015
+	)
+	dL := c1.L - c2.L
+	chroma1 := math.Hypot(c1.A, c1.B)
+	chroma2 := math.Hypot(c2.A, c2.B)
+	dC := chroma1 - chroma2
+	dH2 := square(c1.A-c2.A) + square(c1.B-c2.B) - square(dC)
+	if dH2 < 0 {
+		dH2 = 0
+	}
+	sL := 1.
+	sC := 1 + k1*chroma1
+	sH := 1 + k2*chroma1
+	return math.Sqrt(square(dL/(kL*sL)) + square(dC/(kC*sC)) + dH2/square(kH*sH))
+}
+
+// DeltaE2000 returns the CIEDE2000 color difference with all parametric weighting factors set to 1.
+// https://hajim.rochester.edu/ece/sites/gsharma/ciede2000/
+func DeltaE2000(c1, c2 Lab) float64 {
+	const pow25to7 = 6103515625. // 25^7
+
+	chromaMean := (math.Hypot(c1.A, c1.B) + math.Hypot(c2.A, c2.B)) / 2
+	g := 0.5 * (1 - math.Sqrt(math.Pow(chromaMean, 7)/(math.Pow(chromaMean, 7)+pow25to7)))
+	a1 := (1 + g) * c1.A
+	a2 := (1 + g) * c2.A
+	chroma1 := math.Hypot(a1, c1.B)
+	chroma2 := math.Hypot(a2, c2.B)
+	hue1 := hueDegrees(a1, c1.B)
+	hue2 := hueDegrees(a2, c2.B)
+
+	dL := c2.L - c1.L
+	dC := chroma2 - chroma1
+	dh := 0.
+	if chroma1*chroma2 != 0 {
+		dh = hue2 - hue1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(chroma1*chroma2) * math.Sin(radians(dh/2))
+
+	lightnessMean := (c1.L + c2.L) / 2
+	chromaMean = (chroma1 + chroma2) / 2
+	hueMean := hue1 + hue2
+	if chroma1*chroma2 != 0 {
+		if math.Abs(hue1-hue2) <= 180 {
+			hueMean = (hue1 + hue2) / 2
+		} else if hue1+hue2 < 360 {
+			hueMean = (hue1 + hue2 + 360) / 2
+		} else {
+			hueMean = (hue1 + hue2 - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos(radians(hueMean-30)) +
+		0.24*math.Cos(radians(2*hueMean)) +
+		0.32*math.Cos(radians(3*hueMean+6)) -
+		0.20*math.Cos(radians(4*hueMean-63))
+	dTheta := 30 * math.Exp(-square((hueMean-275)/25))
+	rC := 2 * math.Sqrt(math.Pow(chromaMean, 7)/(math.Pow(chromaMean, 7)+pow25to7))
+	sL := 1 + 0.015*square(lightnessMean-50)/math.Sqrt(20+square(lightnessMean-50))
+	sC := 1 + 0.045*chromaMean
+	sH := 1 + 0.015*chromaMean*t
+	rT := -math.Sin(radians(2*dTheta)) * rC
+
+	return math.Sqrt(square(dL/sL) + square(dC/sC) + square(dH/sH) + rT*(dC/sC)*(dH/sH))
+}
+
+// Nearest returns the index of the palette entry closest to c by CIEDE2000, or -1 if palette is empty.
+func Nearest(c color.Color, palette []color.Color) int {
+	lab := ColorToLab(c, D65)
+	nearest := -1
+	min := math.Inf(1)
+	for i, p := range palette {
+		if d := DeltaE2000(lab, ColorToLab(p, D65)); d < min {
+			min = d
+			nearest = i
+		}
+	}
+	return nearest
+}
+
+func hueDegrees(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func square(v float64) float64 {
+	return v * v
+}
@@ -0,0 +1,120 @@
+package colorspace
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+// sharmaTestData is the CIEDE2000 test data published by Sharma, Wu and Dalal.
+// https://hajim.rochester.edu/ece/sites/gsharma/ciede2000/
+var sharmaTestData = []struct {
+	c1, c2 Lab
+	want   float64
+}{
+	{Lab{50.0000, 2.6772, -79.7751}, Lab{50.0000, 0.0000, -82.7485}, 2.0425},
+	{Lab{50.0000, 3.1571, -77.2803}, Lab{50.0000, 0.0000, -82.7485}, 2.8615},
+	{Lab{50.0000, 2.8361, -74.0200}, Lab{50.0000, 0.0000, -82.7485}, 3.4412},
+	{Lab{50.0000, -1.3802, -84.2814}, Lab{50.0000, 0.0000, -82.7485}, 1.0000},
+	{Lab{50.0000, -1.1848, -84.8006}, Lab{50.0000, 0.0000, -82.7485}, 1.0000},
+	{Lab{50.0000, -0.9009, -85.5211}, Lab{50.0000, 0.0000, -82.7485}, 1.0000},
+	{Lab{50.0000, 0.0000, 0.0000}, Lab{50.0000, -1.0000, 2.0000}, 2.3669},
+	{Lab{50.0000, -1.0000, 2.0000}, Lab{50.0000, 0.0000, 0.0000}, 2.3669},
+	{Lab{50.0000, 2.4900, -0.0010}, Lab{50.0000, -2.4900, 0.0009}, 7.1792},
+	{Lab{50.0000, 2.4900, -0.0010}, Lab{50.0000, -2.4900, 0.0010}, 7.1792},
+	{Lab{50.0000, 2.4900, -0.0010}, Lab{50.0000, -2.4900, 0.0011}, 7.2195},
+	{Lab{50.0000, 2.4900, -0.0010}, Lab{50.0000, -2.4900, 0.0012}, 7.2195},
+	{Lab{50.0000, -0.0010, 2.4900}, Lab{50.0000, 0.0009, -2.4900}, 4.8045},
+	{Lab{50.0000, -0.0010, 2.4900}, Lab{50.0000, 0.0010, -2.4900}, 4.8045},
+	{Lab{50.0000, -0.0010, 2.4900}, Lab{50.0000, 0.0011, -2.4900}, 4.7461},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{50.0000, 0.0000, -2.5000}, 4.3065},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{73.0000, 25.0000, -18.0000}, 27.1492},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{61.0000, -5.0000, 29.0000}, 22.8977},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{56.0000, -27.0000, -3.0000}, 31.9030},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{58.0000, 24.0000, 15.0000}, 19.4535},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{50.0000, 3.1736, 0.5854}, 1.0000},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{50.0000, 3.2972, 0.0000}, 1.0000},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{50.0000, 1.8634, 0.5757}, 1.0000},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{50.0000, 3.2592, 0.3350}, 1.0000},
+	{Lab{60.2574, -34.0099, 36.2677}, Lab{60.4626, -34.1751, 39.4387}, 1.2644},
+	{Lab{63.0109, -31.0961, -5.8663}, Lab{62.8187, -29.7946, -4.0864}, 1.2630},
+	{Lab{61.2901, 3.7196, -5.3901}, Lab{61.4292, 2.2480, -4.9620}, 1.8731},
+	{Lab{35.0831, -44.1164, 3.7933}, Lab{35.0232, -40.0716, 1.5901}, 1.8645},
+	{Lab{22.7233, 20.0904, -46.6940}, Lab{23.0331, 14.9730, -42.5619}, 2.0373},
+	{Lab{36.4612, 47.8580, 18.3852}, Lab{36.2715, 50.5065, 21.2231}, 1.4146},
+	{Lab{90.8027, -2.0831, 1.4410}, Lab{91.1528, -1.6435, 0.0447}, 1.4441},
+	{Lab{90.9257, -0.5406, -0.9208}, Lab{88.6381, -0.8985, -0.7239}, 1.5381},
+	{Lab{6.7747, -0.2908, -2.4247}, Lab{5.8714, -0.0985, -2.2286}, 0.6377},
+	{Lab{2.0776, 0.0795, -1.1350}, Lab{0.9033, -0.0636, -0.5514}, 0.9082},
+}
+
+func TestDeltaE2000(t *testing.T) {
+	for i, test := range sharmaTestData {
+		if got := DeltaE2000(test.c1, test.c2); math.Abs(got-test.want) > 0.00005 {
+			t.Errorf("#%d: DeltaE2000(%v, %v) = %.4f; want %.4f", i+1, test.c1, test.c2, got, test.want)
+		}
+		if got := DeltaE2000(test.c2, test.c1); math.Abs(got-test.want) > 0.00005 {
+			t.Errorf("#%d: DeltaE2000(%v, %v) = %.4f; want %.4f", i+1, test.c2, test.c1, got, test.want)
+		}
+	}
+}
+
+func TestDeltaE76(t *testing.T) {
+	tests := []struct {
+		c1, c2 Lab
+		want   float64
+	}{
+		{Lab{50, 2.6772, -79.7751}, Lab{50, 0, -82.7485}, 4.0011},
+		{Lab{50, 2.5, 0}, Lab{73, 25, -18}, 36.8680},
+		{Lab{50, 0, 0}, Lab{50, -1, 2}, 2.2361},
+		{Lab{50, 0, 0}, Lab{50, 0, 0}, 0},
+	}
+	for _, test := range tests {
+		if got := DeltaE76(test.c1, test.c2); math.Abs(got-test.want) > 0.00005 {
+			t.Errorf("DeltaE76(%v, %v) = %.4f; want %.4f", test.c1, test.c2, got, test.want)
+		}
+	}
+}
+
+func TestDeltaE94(t *testing.T) {
+	tests := []struct {
+		c1, c2 Lab
+		want   float64
+	}{
+		{Lab{50, 2.6772, -79.7751}, Lab{50, 0, -82.7485}, 1.3950},
+		{Lab{50, 2.5, 0}, Lab{73, 25, -18}, 34.6892},
+		{Lab{60.2574, -34.0099, 36.2677}, Lab{60.4626, -34.1751, 39.4387}, 1.3910},
+		{Lab{50, 0, 0}, Lab{50, -1, 2}, 2.2361},
+	}
+	for _, test := range tests {
+		if got := DeltaE94(test.c1, test.c2); math.Abs(got-test.want) > 0.00005 {
+			t.Errorf("DeltaE94(%v, %v) = %.4f; want %.4f", test.c1, test.c2, got, test.want)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	palette := []color.Color{
+		color.NRGBA{0xff, 0x00, 0x00, 0xff},
+		color.NRGBA{0x00, 0xff, 0x00, 0xff},
+		color.NRGBA{0x00, 0x00, 0xff, 0xff},
+		color.NRGBA{0x80, 0x80, 0x80, 0xff},
+	}
+	tests := []struct {
+		c    color.Color
+		want int
+	}{
+		{color.NRGBA{0xf0, 0x10, 0x10, 0xff}, 0},
+		{color.NRGBA{0x20, 0xe0, 0x40, 0xff}, 1},
+		{color.NRGBA{0x10, 0x20, 0xd0, 0xff}, 2},
+		{color.Gray{0x70}, 3},
+	}
+	for _, test := range tests {
+		if got := Nearest(test.c, palette); got != test.want {
+			t.Errorf("Nearest(%v) = %d; want %d", test.c, got, test.want)
+		}
+	}
+	if got := Nearest(color.White, nil); got != -1 {
+		t.Errorf("Nearest(white, nil) = %d; want -1", got)
+	}
+}
@@ -0,0 +1,159 @@
+package colorspace
+
+import (
+	"image/color"
+	"math"
+)
+
+// OKLab represents a color in the OKLab color space. L is in the range [0, 1].
+type OKLab struct {
+	L, A, B float64
+}
+
+// OKLCh represents a color in the OKLCh color space, the cylindrical form of OKLab. H is in degrees [0, 360).
+type OKLCh struct {
+	L, C, H float64
+}
+
+const (
+	gamutMapJND     = 0.02
+	gamutMapEpsilon = 0.0001
+)
+
+// LinearSRGBToOKLab converts linear sRGB to OKLab.
+func LinearSRGBToOKLab(r, g, b float64) OKLab {
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return OKLab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// OKLabToLinearSRGB converts OKLab to linear sRGB. The result is not clipped to [0, 1].
+func OKLabToLinearSRGB(c OKLab) (r, g, b float64) {
+	l := cube(c.L + 0.3963377774*c.A + 0.2158037573*c.B)
+	m := cube(c.L - 0.1055613458*c.A - 0.0638541728*c.B)
+	s := cube(c.L - 0.0894841775*c.A - 1.2914855480*c.B)
+
+	return +4.0767416621*l - 3.3077115913*m + 0.2309699292*s,
+		-1.2684380046*l + 2.6097574011*m - 0.3413193965*s,
+		-0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+}
+
+// OKLabToOKLCh converts OKLab to OKLCh.
+func OKLabToOKLCh(c OKLab) OKLCh {
+	return OKLCh{
+		L: c.L,
+		C: math.Hypot(c.A, c.B),
+		H: hueDegrees(c.A, c.B),
+	}
+}
+
+// OKLChToOKLab converts OKLCh to OKLab.
+func OKLChToOKLab(c OKLCh) OKLab {
+	rad := radians(c.H)
+	return OKLab{
+		L: c.L,
+		A: c.C * math.Cos(rad),
+		B: c.C * math.Sin(rad),
+	}
+}
+
+// ColorToOKLab converts c to OKLab, treating it as sRGB.
+func ColorToOKLab(c color.Color) OKLab {
+	r, g, b, _ := toFloatRGBA(c)
+	return LinearSRGBToOKLab(SRGBToLinear(r), SRGBToLinear(g), SRGBToLinear(b))
+}
+
+// OKLabToColor converts OKLab to sRGB, mapping it into the sRGB gamut with GamutMapSRGB. Alpha is set to opaque.
+func OKLabToColor(c OKLab) color.NRGBA {
+	r, g, b := GamutMapSRGB(OKLabToOKLCh(c))
+	return color.NRGBA{
+		R: toUint8(r),
+		G: toUint8(g),
+		B: toUint8(b),
+		A: 0xff,
+	}
+}
+
+// GamutMapSRGB returns gamma encoded sRGB for c, reducing its chroma until it fits into the sRGB gamut,
+// following the CSS Color 4 gamut mapping algorithm.
+// https://www.w3.org/TR/css-color-4/#binsearch
+func GamutMapSRGB(c OKLCh) (r, g, b float64) {
+	if c.L >= 1 {
+		return 1, 1, 1
+	}
+	if c.L <= 0 {
+		return 0, 0, 0
+	}
+
+	r, g, b = oklchToSRGB(c)
+	if isInSRGBGamut(r, g, b) {
+		return clamp01(r), clamp01(g), clamp01(b)
+	}
+
+	cr, cg, cb := clamp01(r), clamp01(g), clamp01(b)
+	if deltaEOK(cr, cg, cb, c) < gamutMapJND {
+		return cr, cg, cb
+	}
+
+	min, max := 0., c.C
+	minInGamut := true
+	current := c
+	for max-min > gamutMapEpsilon {
+		current.C = (min + max) / 2
+		r, g, b = oklchToSRGB(current)
+		if minInGamut && isInSRGBGamut(r, g, b) {
+			min = current.C
+			continue
+		}
+		cr, cg, cb = clamp01(r), clamp01(g), clamp01(b)
+		e := deltaEOK(cr, cg, cb, current)
+		if e < gamutMapJND {
+			if gamutMapJND-e < gamutMapEpsilon {
+				break
+			}
+			minInGamut = false
+			min = current.C
+		} else {
+			max = current.C
+		}
+	}
+	return cr, cg, cb
+}
+
+// IsInSRGBGamut reports whether c can be represented in sRGB without clipping.
+func IsInSRGBGamut(c OKLCh) bool {
+	return isInSRGBGamut(oklchToSRGB(c))
+}
+
+func oklchToSRGB(c OKLCh) (float64, float64, float64) {
+	r, g, b := OKLabToLinearSRGB(OKLChToOKLab(c))
+	return LinearToSRGB(r), LinearToSRGB(g), LinearToSRGB(b)
+}
+
+func isInSRGBGamut(r, g, b float64) bool {
+	const e = 0.000001
+	return -e <= r && r <= 1+e && -e <= g && g <= 1+e && -e <= b && b <= 1+e
+}
+
+func deltaEOK(r, g, b float64, c OKLCh) float64 {
+	c1 := LinearSRGBToOKLab(SRGBToLinear(r), SRGBToLinear(g), SRGBToLinear(b))
+	c2 := OKLChToOKLab(c)
+	return math.Sqrt(square(c1.L-c2.L) + square(c1.A-c2.A) + square(c1.B-c2.B))
+}
+
+func clamp01(v float64) float64 {
+	if math.IsNaN(v) {
+		return 0
+	}
+	return math.Max(0, math.Min(1, v))
+}
+
+func cube(v float64) float64 {
+	return v * v * v
+}
@@ -0,0 +1,52 @@
+package colorspace
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGamutMapSRGB(t *testing.T) {
+	const maxChroma = 0.4
+	for _, l := range []float64{0.2, 0.5, 0.7, 0.9} {
+		for h := 0.; h < 360; h += 10 {
+			c := OKLCh{l, maxChroma, h}
+			r, g, b := GamutMapSRGB(c)
+			if !isInSRGBGamut(r, g, b) {
+				t.Errorf("GamutMapSRGB(%v) = %f, %f, %f; not in sRGB gamut", c, r, g, b)
+				continue
+			}
+			got := OKLabToOKLCh(LinearSRGBToOKLab(SRGBToLinear(r), SRGBToLinear(g), SRGBToLinear(b)))
+			if math.Abs(got.L-l) > gamutMapJND*2 || got.C >= maxChroma || hueDistance(got.H, h) > 18 {
+				t.Errorf("GamutMapSRGB(%v) = %v; too far from original", c, got)
+			}
+		}
+	}
+	if r, g, b := GamutMapSRGB(OKLCh{1.2, 0.1, 180}); r != 1 || g != 1 || b != 1 {
+		t.Errorf("GamutMapSRGB(1.2, 0.1, 180) = %f, %f, %f; want white", r, g, b)
+	}
+	if r, g, b := GamutMapSRGB(OKLCh{0, 0.1, 180}); r != 0 || g != 0 || b != 0 {
+		t.Errorf("GamutMapSRGB(0, 0.1, 180) = %f, %f, %f; want black", r, g, b)
+	}
+}
+
+func TestIsInSRGBGamut(t *testing.T) {
+	tests := []struct {
+		c    OKLCh
+		want bool
+	}{
+		{OKLCh{0.627955, 0.257683, 29.2339}, true},
+		{OKLCh{0.5, 0, 0}, true},
+		{OKLCh{0.7, 0.4, 145}, false},
+		{OKLCh{0.9, 0.3, 270}, false},
+	}
+	for _, test := range tests {
+		if got := IsInSRGBGamut(test.c); got != test.want {
+			t.Errorf("IsInSRGBGamut(%v) = %v; want %v", test.c, got, test.want)
+		}
+	}
+}
+
+func hueDistance(h1, h2 float64) float64 {
+	d := math.Abs(h1 - h2)
+	return math.Min(d, 360-d)
+}
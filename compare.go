@@ -0,0 +1,141 @@
+package colorpicker
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+var compareSwatchMinSize = fyne.NewSize(80, 32)
+
+// disableListenable is implemented by the pickers of this package.
+type disableListenable interface {
+	addDisableListener(func())
+}
+
+// CompareSwatch is a swatch split into the original color on the left and the current color of a ColorPicker on the right.
+// Tapping the original half reverts the picker to the original color, unless the picker is disabled.
+type CompareSwatch struct {
+	widget.BaseWidget
+
+	picker            ColorPicker
+	original, current color.Color
+}
+
+// NewCompareSwatch returns a swatch comparing the color of picker with the color when it is created.
+// The current half only follows the color of picker if picker was created by this package.
+func NewCompareSwatch(picker ColorPicker) *CompareSwatch {
+	s := &CompareSwatch{
+		picker:   picker,
+		original: picker.Color(),
+		current:  picker.Color(),
+	}
+	if l, ok := picker.(changeListenable); ok {
+		l.addChangeListener(s.setCurrent)
+	}
+	if l, ok := picker.(disableListenable); ok {
+		l.addDisableListener(s.Refresh)
+	}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+// Original returns the color displayed as the original.
+func (s *CompareSwatch) Original() color.Color {
+	return s.original
+}
+
+// SetOriginal sets the color displayed as the original.
+func (s *CompareSwatch) SetOriginal(c color.Color) {
+	s.original = c
+	s.Refresh()
+}
+
+// Revert sets the original color on the picker.
+func (s *CompareSwatch) Revert() {
+	s.picker.SetColor(s.original)
+}
+
+func (s *CompareSwatch) setCurrent(c color.Color) {
+	s.current = c
+	s.Refresh()
+}
+
+func (s *CompareSwatch) Tapped(e *fyne.PointEvent) {
+	if !s.picker.Disabled() && e.Position.X < s.Size().Width/2 {
+		s.Revert()
+	}
+}
+
+func (s *CompareSwatch) Cursor() desktop.Cursor {
+	if s.picker.Disabled() {
+		return desktop.DefaultCursor
+	}
+	return desktop.PointerCursor
+}
+
+func (s *CompareSwatch) CreateRenderer() fyne.WidgetRenderer {
+	r := &compareSwatchRenderer{
+		swatch:     s,
+		background: newCheckeredBackground(defaultCheckerboardColors[0], defaultCheckerboardColors[1]),
+		disabledBackground: newCheckeredBackground(
+			disabledColor(defaultCheckerboardColors[0]),
+			disabledColor(defaultCheckerboardColors[1]),
+		),
+		original: &canvas.Rectangle{},
+		current:  &canvas.Rectangle{},
+		border:   &canvas.Rectangle{FillColor: transparent, StrokeWidth: 1},
+	}
+	r.Refresh()
+	return r
+}
+
+type compareSwatchRenderer struct {
+	swatch *CompareSwatch
+	// disabledBackground replaces background while the picker is disabled.
+	background, disabledBackground *canvas.Raster
+	original, current              *canvas.Rectangle
+	border                         *canvas.Rectangle
+}
+
+func (r *compareSwatchRenderer) Layout(size fyne.Size) {
+	half := fyne.NewSize(size.Width/2, size.Height)
+	r.background.Resize(size)
+	r.disabledBackground.Resize(size)
+	r.original.Resize(half)
+	r.current.Resize(half)
+	r.current.Move(fyne.NewPos(half.Width, 0))
+	r.border.Resize(size)
+}
+
+func (r *compareSwatchRenderer) MinSize() fyne.Size {
+	return compareSwatchMinSize
+}
+
+func (r *compareSwatchRenderer) Refresh() {
+	r.original.FillColor = r.swatch.original
+	r.current.FillColor = r.swatch.current
+	disabled := r.swatch.picker.Disabled()
+	if disabled {
+		r.original.FillColor = disabledColor(r.swatch.original)
+		r.current.FillColor = disabledColor(r.swatch.current)
+	}
+	r.background.Hidden = disabled
+	r.disabledBackground.Hidden = !disabled
+	r.border.StrokeColor = theme.InputBorderColor()
+	canvas.Refresh(r.background)
+	canvas.Refresh(r.disabledBackground)
+	canvas.Refresh(r.original)
+	canvas.Refresh(r.current)
+	canvas.Refresh(r.border)
+}
+
+func (r *compareSwatchRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.background, r.disabledBackground, r.original, r.current, r.border}
+}
+
+func (r *compareSwatchRenderer) Destroy() {}
@@ -0,0 +1,111 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestCompareSwatch(t *testing.T) {
+	test.NewTempApp(t)
+	original := color.NRGBA{R: 0xff, A: 0xff}
+	p := NewWithOptions(StyleHue, WithInitialColor(original))
+	s := NewCompareSwatch(p)
+	s.Resize(compareSwatchMinSize)
+
+	current := color.NRGBA{B: 0xff, A: 0x80}
+	p.SetColor(current)
+	if !colorEquals(s.current, current) || !colorEquals(s.Original(), original) {
+		t.Errorf("colors = %v, %v; want %v, %v", s.Original(), s.current, original, current)
+	}
+
+	// tapping the current half does nothing
+	test.TapAt(s, fyne.NewPos(60, 10))
+	if got := p.Color(); !colorEquals(got, current) {
+		t.Errorf("Color() = %v after tapping the current half; want %v", got, current)
+	}
+	test.TapAt(s, fyne.NewPos(20, 10))
+	if got := p.Color(); !colorEquals(got, original) {
+		t.Errorf("Color() = %v after tapping the original half; want %v", got, original)
+	}
+	if !colorEquals(s.current, original) {
+		t.Errorf("current = %v after reverting; want %v", s.current, original)
+	}
+}
+
+func TestCompareSwatchDisabled(t *testing.T) {
+	test.NewTempApp(t)
+	original := color.NRGBA{R: 0xff, A: 0xff}
+	p := NewWithOptions(StyleHue, WithInitialColor(original))
+	s := NewCompareSwatch(p)
+	s.Resize(compareSwatchMinSize)
+	r := test.WidgetRenderer(s).(*compareSwatchRenderer)
+	p.SetColor(color.Black)
+
+	p.Disable()
+	test.TapAt(s, fyne.NewPos(20, 10))
+	if got := p.Color(); !colorEquals(got, color.Black) {
+		t.Errorf("Color() = %v after tapping the original half while disabled; want black", got)
+	}
+	if got, want := r.original.FillColor, disabledColor(original); got != want {
+		t.Errorf("original fill = %v while disabled; want %v", got, want)
+	}
+	if r.background.Visible() || !r.disabledBackground.Visible() {
+		t.Error("the checkerboard is not dimmed while disabled")
+	}
+
+	p.Enable()
+	if got := r.original.FillColor; got != original || !r.background.Visible() {
+		t.Errorf("original fill = %v after Enable; want %v over the checkerboard", got, original)
+	}
+	test.TapAt(s, fyne.NewPos(20, 10))
+	if got := p.Color(); !colorEquals(got, original) {
+		t.Errorf("Color() = %v after tapping the original half; want %v", got, original)
+	}
+}
+
+func TestColorSelectModalRectCompareSwatch(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 400))
+	original := color.NRGBA{R: 0xff, A: 0xff}
+	rect := NewColorSelectModalRect(w, fyne.NewSize(20, 20), original).(*colorSelectModalRect)
+	w.SetContent(rect)
+
+	test.Tap(rect)
+	s := findCompareSwatch(w.Canvas().Overlays().Top())
+	if s == nil {
+		t.Fatal("no compare swatch in the dialog")
+	}
+	s.picker.SetColor(color.Black)
+	if got := rect.color(); !colorEquals(got, color.Black) {
+		t.Errorf("rect color = %v; want black", got)
+	}
+	s.Revert()
+	if got := rect.color(); !colorEquals(got, original) {
+		t.Errorf("rect color = %v after reverting; want %v", got, original)
+	}
+}
+
+func findCompareSwatch(o fyne.CanvasObject) *CompareSwatch {
+	switch o := o.(type) {
+	case *CompareSwatch:
+		return o
+	case *fyne.Container:
+		for _, child := range o.Objects {
+			if s := findCompareSwatch(child); s != nil {
+				return s
+			}
+		}
+	case fyne.Widget:
+		for _, child := range test.WidgetRenderer(o).Objects() {
+			if s := findCompareSwatch(child); s != nil {
+				return s
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package csscolor
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/lusingander/colorpicker/colorspace"
+)
+
+// Notation represents how Format writes a color.
+type Notation int
+
+const (
+	// NotationHex is #rrggbb, or #rrggbbaa if not opaque.
+	NotationHex Notation = iota
+	// NotationRGB is rgb(r g b) with r, g, b in [0, 255].
+	NotationRGB
+	// NotationHSL is hsl(h s% l%).
+	NotationHSL
+	// NotationHWB is hwb(h w% b%).
+	NotationHWB
+	// NotationLab is lab(L a b) relative to D50.
+	NotationLab
+	// NotationLCh is lch(L C h) relative to D50.
+	NotationLCh
+	// NotationOKLab is oklab(L a b).
+	NotationOKLab
+	// NotationOKLCh is oklch(L C h).
+	NotationOKLCh
+	// NotationDisplayP3 is color(display-p3 r g b).
+	NotationDisplayP3
+	// NotationNamed is the CSS color name, falling back to NotationHex if the color has no name.
+	NotationNamed
+)
+
+// Notations are all notations supported by Format.
+var Notations = []Notation{
+	NotationHex,
+	NotationRGB,
+	NotationHSL,
+	NotationHWB,
+	NotationLab,
+	NotationLCh,
+	NotationOKLab,
+	NotationOKLCh,
+	NotationDisplayP3,
+	NotationNamed,
+}
+
+func (n Notation) String() string {
+	switch n {
+	case NotationHex:
+		return "hex"
+	case NotationRGB:
+		return "rgb"
+	case NotationHSL:
+		return "hsl"
+	case NotationHWB:
+		return "hwb"
+	case NotationLab:
+		return "lab"
+	case NotationLCh:
+		return "lch"
+	case NotationOKLab:
+		return "oklab"
+	case NotationOKLCh:
+		return "oklch"
+	case NotationDisplayP3:
+		return "display-p3"
+	case NotationNamed:
+		return "named"
+	default:
+		return "Notation(" + strconv.Itoa(int(n)) + ")"
+	}
+}
+
+// colorNames maps colors to their name, preferring the alphabetically first name (aqua over cyan, gray over grey).
+var colorNames = func() map[color.NRGBA]string {
+	names := make([]string, 0, len(namedColors))
+	for name := range namedColors {
+		names = append(names, name)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	m := make(map[color.NRGBA]string, len(names))
+	for _, name := range names {
+		m[namedColors[name]] = name
+	}
+	return m
+}()
+
+// Format returns c written in the given notation. Precision is chosen so that
+// parsing the result with Parse gives back the same 8 bit color.
+func Format(c color.Color, n Notation) string {
+	rgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	r, g, b := float64(rgba.R)/255, float64(rgba.G)/255, float64(rgba.B)/255
+
+	switch n {
+	case NotationRGB:
+		return fmt.Sprintf("rgb(%d %d %d%s)", rgba.R, rgba.G, rgba.B, formatAlpha(rgba.A))
+	case NotationHSL:
+		h, s, l := srgbToHSL(r, g, b)
+		return fmt.Sprintf("hsl(%s %s%% %s%%%s)",
+			formatNumber(h, 2), formatNumber(s*100, 2), formatNumber(l*100, 2), formatAlpha(rgba.A))
+	case NotationHWB:
+		h, _, _ := srgbToHSL(r, g, b)
+		w := math.Min(r, math.Min(g, b))
+		bl := 1 - math.Max(r, math.Max(g, b))
+		return fmt.Sprintf("hwb(%s %s%% %s%%%s)",
+			formatNumber(h, 2), formatNumber(w*100, 2), formatNumber(bl*100, 2), formatAlpha(rgba.A))
+	case NotationLab:
+		lab := colorspace.ColorToLab(opaque(rgba), colorspace.D50)
+		return fmt.Sprintf("lab(%s %s %s%s)",
+			formatNumber(lab.L, 2), formatNumber(lab.A, 2), formatNumber(lab.B, 2), formatAlpha(rgba.A))
+	case NotationLCh:
+		lch := colorspace.LabToLCh(colorspace.ColorToLab(opaque(rgba), colorspace.D50))
+		return fmt.Sprintf("lch(%s %s %s%s)",
+			formatNumber(lch.L, 2), formatNumber(lch.C, 2), formatHue(lch.H, lch.C, 0.005), formatAlpha(rgba.A))
+	case NotationOKLab:
+		lab := colorspace.ColorToOKLab(opaque(rgba))
+		return fmt.Sprintf("oklab(%s %s %s%s)",
+			formatNumber(lab.L, 4), formatNumber(lab.A, 4), formatNumber(lab.B, 4), formatAlpha(rgba.A))
+	case NotationOKLCh:
+		lch := colorspace.OKLabToOKLCh(colorspace.ColorToOKLab(opaque(rgba)))
+		return fmt.Sprintf("oklch(%s %s %s%s)",
+			formatNumber(lch.L, 4), formatNumber(lch.C, 4), formatHue(lch.H, lch.C, 0.00005), formatAlpha(rgba.A))
+	case NotationDisplayP3:
+		xyz := colorspace.ColorToXYZ(opaque(rgba))
+		pr, pg, pb := colorspace.XYZToLinearDisplayP3(xyz)
+		return fmt.Sprintf("color(display-p3 %s %s %s%s)",
+			formatNumber(colorspace.LinearToSRGB(pr), 4),
+			formatNumber(colorspace.LinearToSRGB(pg), 4),
+			formatNumber(colorspace.LinearToSRGB(pb), 4),
+			formatAlpha(rgba.A))
+	case NotationNamed:
+		if rgba == (color.NRGBA{}) {
+			return "transparent"
+		}
+		if name, ok := colorNames[rgba]; ok {
+			return name
+		}
+	}
+
+	if rgba.A == 0xff {
+		return fmt.Sprintf("#%.2x%.2x%.2x", rgba.R, rgba.G, rgba.B)
+	}
+	return fmt.Sprintf("#%.2x%.2x%.2x%.2x", rgba.R, rgba.G, rgba.B, rgba.A)
+}
+
+func opaque(c color.NRGBA) color.NRGBA {
+	c.A = 0xff
+	return c
+}
+
+func srgbToHSL(r, g, b float64) (h, s, l float64) {
+	min := math.Min(r, math.Min(g, b))
+	max := math.Max(r, math.Max(g, b))
+	l = (max + min) / 2
+	d := max - min
+	if d == 0 {
+		return 0, 0, l
+	}
+	s = d / (1 - math.Abs(max+min-1))
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d+6, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h * 60, s, l
+}
+
+func formatAlpha(a uint8) string {
+	if a == 0xff {
+		return ""
+	}
+	return " / " + formatNumber(float64(a)/255, 3)
+}
+
+// formatHue writes the hue, or none if the chroma is too small for the hue to be meaningful.
+func formatHue(h, c, achromatic float64) string {
+	if c < achromatic {
+		return "none"
+	}
+	return formatNumber(h, 2)
+}
+
+func formatNumber(v float64, decimals int) string {
+	p := math.Pow(10, float64(decimals))
+	v = math.Round(v*p) / p
+	if v == 0 {
+		v = 0 // avoid -0
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
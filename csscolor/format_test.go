@@ -0,0 +1,94 @@
+package csscolor
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		c    color.Color
+		n    Notation
+		want string
+	}{
+		{color.NRGBA{0xff, 0x80, 0x00, 0xff}, NotationHex, "#ff8000"},
+		{color.NRGBA{0xff, 0x80, 0x00, 0x80}, NotationHex, "#ff800080"},
+		{color.RGBA{0x80, 0x40, 0x00, 0x80}, NotationHex, "#ff7f0080"},
+		{color.NRGBA{0xff, 0x80, 0x00, 0xff}, NotationRGB, "rgb(255 128 0)"},
+		{color.NRGBA{0xff, 0x80, 0x00, 0x80}, NotationRGB, "rgb(255 128 0 / 0.502)"},
+		{color.NRGBA{0x00, 0xff, 0x00, 0xff}, NotationHSL, "hsl(120 100% 50%)"},
+		{color.NRGBA{0x80, 0x80, 0x80, 0xff}, NotationHSL, "hsl(0 0% 50.2%)"},
+		{color.NRGBA{0x33, 0x33, 0x99, 0x40}, NotationHWB, "hwb(240 20% 40% / 0.251)"},
+		{color.NRGBA{0xff, 0x00, 0x00, 0xff}, NotationLab, "lab(54.29 80.8 69.89)"},
+		{color.NRGBA{0xff, 0x00, 0x00, 0xff}, NotationLCh, "lch(54.29 106.84 40.86)"},
+		{color.NRGBA{0x80, 0x80, 0x80, 0xff}, NotationLCh, "lch(53.59 0 none)"},
+		{color.NRGBA{0xff, 0x00, 0x00, 0xff}, NotationOKLab, "oklab(0.628 0.2249 0.1258)"},
+		{color.NRGBA{0xff, 0x00, 0x00, 0xff}, NotationOKLCh, "oklch(0.628 0.2577 29.23)"},
+		{color.NRGBA{0xff, 0xff, 0xff, 0xff}, NotationOKLCh, "oklch(1 0 none)"},
+		{color.NRGBA{0xff, 0x00, 0x00, 0xff}, NotationDisplayP3, "color(display-p3 0.9175 0.2003 0.1386)"},
+		{color.NRGBA{0x00, 0xff, 0xff, 0xff}, NotationNamed, "aqua"},
+		{color.NRGBA{0x80, 0x80, 0x80, 0xff}, NotationNamed, "gray"},
+		{color.NRGBA{0x00, 0x00, 0x00, 0x00}, NotationNamed, "transparent"},
+		{color.NRGBA{0x12, 0x34, 0x56, 0xff}, NotationNamed, "#123456"},
+	}
+	for _, test := range tests {
+		if got := Format(test.c, test.n); got != test.want {
+			t.Errorf("Format(%v, %v) = %q; want %q", test.c, test.n, got, test.want)
+		}
+	}
+}
+
+func TestFormatAndParse(t *testing.T) {
+	for _, n := range Notations {
+		for r := 0; r <= 255; r += 15 {
+			for g := 0; g <= 255; g += 15 {
+				for b := 0; b <= 255; b += 15 {
+					want := color.NRGBA{uint8(r), uint8(g), uint8(b), uint8(r ^ g ^ b)}
+					s := Format(want, n)
+					got, err := Parse(s)
+					if err != nil {
+						t.Errorf("Parse(%q) returned error: %v", s, err)
+						continue
+					}
+					if !roundTrips(n, want, got) {
+						t.Errorf("Parse(Format(%v, %v)) = Parse(%q) = %v", want, n, s, got)
+					}
+				}
+			}
+		}
+	}
+}
+
+func FuzzFormat(f *testing.F) {
+	f.Add(uint8(0xff), uint8(0x80), uint8(0x00), uint8(0xff), 0)
+	f.Add(uint8(0x12), uint8(0x34), uint8(0x56), uint8(0x78), 4)
+	f.Fuzz(func(t *testing.T, r, g, b, a uint8, n int) {
+		if n < 0 || len(Notations) <= n {
+			return
+		}
+		want := color.NRGBA{r, g, b, a}
+		s := Format(want, Notations[n])
+		got, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", s, err)
+		}
+		if !roundTrips(Notations[n], want, got) {
+			t.Fatalf("Parse(Format(%v, %v)) = Parse(%q) = %v", want, Notations[n], s, got)
+		}
+	})
+}
+
+// roundTrips reports whether got is want after formatting in n and parsing.
+// Notations which are not based on sRGB may differ by 1 due to rounding.
+func roundTrips(n Notation, want, got color.NRGBA) bool {
+	switch n {
+	case NotationLab, NotationLCh, NotationOKLab, NotationOKLCh, NotationDisplayP3:
+		return want.A == got.A && near(want.R, got.R) && near(want.G, got.G) && near(want.B, got.B)
+	default:
+		return want == got
+	}
+}
+
+func near(a, b uint8) bool {
+	return a-b <= 1 || b-a <= 1
+}
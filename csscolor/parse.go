@@ -0,0 +1,625 @@
+// Package csscolor parses and formats colors written in the CSS Color Module Level 4 syntax.
+//
+// https://www.w3.org/TR/css-color-4/
+package csscolor
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lusingander/colorpicker/colorspace"
+)
+
+// ParseError describes why a string could not be parsed as a CSS color.
+type ParseError struct {
+	// Input is the string passed to Parse.
+	Input string
+	// Pos is the byte offset in Input where the problem was found.
+	Pos int
+	// Reason describes the problem.
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("csscolor: invalid color %q at position %d: %s", e.Input, e.Pos, e.Reason)
+}
+
+// Parse parses a CSS color: a hex color (#rgb, #rgba, #rrggbb, #rrggbbaa), a named color, transparent,
+// or one of the functions rgb(), rgba(), hsl(), hsla(), hwb(), lab(), lch(), oklab(), oklch() and color().
+// Colors outside the sRGB gamut are mapped into it using the CSS Color 4 gamut mapping algorithm.
+// If the string cannot be parsed, the returned error is a *ParseError.
+func Parse(s string) (color.NRGBA, error) {
+	p := &parser{input: s}
+	p.skipSpace()
+	if p.eof() {
+		return color.NRGBA{}, p.errorf(p.pos, "empty color")
+	}
+
+	var c color.NRGBA
+	var err error
+	if p.input[p.pos] == '#' {
+		c, err = p.parseHex()
+	} else {
+		start := p.pos
+		name := strings.ToLower(p.readIdent())
+		switch {
+		case name == "":
+			return color.NRGBA{}, p.errorf(start, "unexpected character %q", p.input[start])
+		case !p.eof() && p.input[p.pos] == '(':
+			p.pos++
+			c, err = p.parseFunction(name, start)
+		case name == "transparent":
+			c = color.NRGBA{}
+		default:
+			var ok bool
+			if c, ok = namedColors[name]; !ok {
+				return color.NRGBA{}, p.errorf(start, "unknown color name %q", name)
+			}
+		}
+	}
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+
+	p.skipSpace()
+	if !p.eof() {
+		return color.NRGBA{}, p.errorf(p.pos, "unexpected %q after color", p.input[p.pos:])
+	}
+	return c, nil
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenPercentage
+	tokenDimension
+	tokenIdent
+	tokenComma
+	tokenSlash
+	tokenCloseParen
+)
+
+type token struct {
+	kind  tokenKind
+	pos   int
+	value float64
+	text  string // unit of dimension or name of ident, lower cased
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) *ParseError {
+	return &ParseError{
+		Input:  p.input,
+		Pos:    pos,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && isSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) readIdent() string {
+	start := p.pos
+	for !p.eof() && (isIdentChar(p.input[p.pos]) || (p.pos > start && isDigit(p.input[p.pos]))) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) parseHex() (color.NRGBA, error) {
+	start := p.pos
+	p.pos++ // #
+	digits := p.pos
+	for !p.eof() && isHexDigit(p.input[p.pos]) {
+		p.pos++
+	}
+	if !p.eof() && !isSpace(p.input[p.pos]) {
+		return color.NRGBA{}, p.errorf(p.pos, "invalid hex digit %q", p.input[p.pos])
+	}
+
+	hex := p.input[digits:p.pos]
+	v := make([]uint8, 0, 4)
+	switch len(hex) {
+	case 3, 4:
+		for i := 0; i < len(hex); i++ {
+			d := hexValue(hex[i])
+			v = append(v, d<<4|d)
+		}
+	case 6, 8:
+		for i := 0; i < len(hex); i += 2 {
+			v = append(v, hexValue(hex[i])<<4|hexValue(hex[i+1]))
+		}
+	default:
+		return color.NRGBA{}, p.errorf(start, "hex color must have 3, 4, 6 or 8 digits, got %d", len(hex))
+	}
+	if len(v) == 3 {
+		v = append(v, 0xff)
+	}
+	return color.NRGBA{R: v[0], G: v[1], B: v[2], A: v[3]}, nil
+}
+
+// next reads the next token inside a function.
+func (p *parser) next() (token, error) {
+	p.skipSpace()
+	if p.eof() {
+		return token{}, p.errorf(p.pos, "missing ')'")
+	}
+	start := p.pos
+	switch ch := p.input[p.pos]; {
+	case ch == ',':
+		p.pos++
+		return token{kind: tokenComma, pos: start}, nil
+	case ch == '/':
+		p.pos++
+		return token{kind: tokenSlash, pos: start}, nil
+	case ch == ')':
+		p.pos++
+		return token{kind: tokenCloseParen, pos: start}, nil
+	case isDigit(ch) || ch == '.' || ch == '+' || (ch == '-' && p.startsNumberAfterSign()):
+		return p.readNumeric()
+	case isIdentChar(ch):
+		return token{kind: tokenIdent, pos: start, text: strings.ToLower(p.readIdent())}, nil
+	default:
+		return token{}, p.errorf(start, "unexpected character %q", ch)
+	}
+}
+
+func (p *parser) startsNumberAfterSign() bool {
+	if p.pos+1 >= len(p.input) {
+		return false
+	}
+	ch := p.input[p.pos+1]
+	return isDigit(ch) || ch == '.'
+}
+
+func (p *parser) readNumeric() (token, error) {
+	start := p.pos
+	if ch := p.input[p.pos]; ch == '+' || ch == '-' {
+		p.pos++
+	}
+	digits := 0
+	for !p.eof() && isDigit(p.input[p.pos]) {
+		p.pos++
+		digits++
+	}
+	if !p.eof() && p.input[p.pos] == '.' {
+		p.pos++
+		for !p.eof() && isDigit(p.input[p.pos]) {
+			p.pos++
+			digits++
+		}
+	}
+	if digits == 0 {
+		return token{}, p.errorf(start, "invalid number")
+	}
+	if !p.eof() && (p.input[p.pos] == 'e' || p.input[p.pos] == 'E') {
+		exp := p.pos + 1
+		if exp < len(p.input) && (p.input[exp] == '+' || p.input[exp] == '-') {
+			exp++
+		}
+		if exp < len(p.input) && isDigit(p.input[exp]) {
+			p.pos = exp
+			for !p.eof() && isDigit(p.input[p.pos]) {
+				p.pos++
+			}
+		}
+	}
+	v, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil || math.IsInf(v, 0) {
+		return token{}, p.errorf(start, "invalid number %q", p.input[start:p.pos])
+	}
+
+	if !p.eof() && p.input[p.pos] == '%' {
+		p.pos++
+		return token{kind: tokenPercentage, pos: start, value: v}, nil
+	}
+	if !p.eof() && isIdentChar(p.input[p.pos]) {
+		unit := strings.ToLower(p.readIdent())
+		return token{kind: tokenDimension, pos: start, value: v, text: unit}, nil
+	}
+	return token{kind: tokenNumber, pos: start, value: v}, nil
+}
+
+// arguments are the components of a color function.
+type arguments struct {
+	values []token
+	alpha  *token
+	legacy bool // comma separated syntax
+	end    int  // position of ')'
+}
+
+func (p *parser) parseArguments() (*arguments, error) {
+	args := &arguments{}
+	var tokens []token
+	for {
+		t, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if t.kind == tokenCloseParen {
+			args.end = t.pos
+			break
+		}
+		tokens = append(tokens, t)
+	}
+	if len(tokens) == 0 {
+		return nil, p.errorf(args.end, "missing arguments")
+	}
+
+	args.legacy = len(tokens) > 1 && tokens[1].kind == tokenComma
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if args.legacy {
+			if i%2 == 1 {
+				if t.kind != tokenComma {
+					return nil, p.errorf(t.pos, "expected ',' in comma separated syntax")
+				}
+				if i == len(tokens)-1 {
+					return nil, p.errorf(args.end, "missing value after ','")
+				}
+				continue
+			}
+			if !t.isValue() {
+				return nil, p.errorf(t.pos, "expected value")
+			}
+			if t.kind == tokenIdent {
+				return nil, p.errorf(t.pos, "%q is not allowed in comma separated syntax", t.text)
+			}
+			if len(args.values) == 3 {
+				args.alpha = &tokens[i]
+				continue
+			}
+			args.values = append(args.values, t)
+			continue
+		}
+
+		switch {
+		case t.kind == tokenComma:
+			return nil, p.errorf(t.pos, "unexpected ',' in space separated syntax")
+		case t.kind == tokenSlash:
+			if i != len(tokens)-2 {
+				return nil, p.errorf(t.pos, "'/' must be followed by exactly one alpha value")
+			}
+			if !tokens[i+1].isValue() {
+				return nil, p.errorf(tokens[i+1].pos, "expected alpha value")
+			}
+			args.alpha = &tokens[i+1]
+			i++
+		default:
+			args.values = append(args.values, t)
+		}
+	}
+	if args.legacy && len(tokens) > 7 {
+		return nil, p.errorf(tokens[8].pos, "too many arguments")
+	}
+	return args, nil
+}
+
+func (t token) isValue() bool {
+	return t.kind == tokenNumber || t.kind == tokenPercentage || t.kind == tokenDimension || t.kind == tokenIdent
+}
+
+func (p *parser) parseFunction(name string, start int) (color.NRGBA, error) {
+	var space string
+	if name == "color" {
+		t, err := p.next()
+		if err != nil {
+			return color.NRGBA{}, err
+		}
+		if t.kind != tokenIdent {
+			return color.NRGBA{}, p.errorf(t.pos, "expected color space")
+		}
+		space = t.text
+		if space != "srgb" && space != "srgb-linear" && space != "display-p3" {
+			return color.NRGBA{}, p.errorf(t.pos, "unsupported color space %q", space)
+		}
+	}
+
+	args, err := p.parseArguments()
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	if len(args.values) != 3 {
+		return color.NRGBA{}, p.errorf(args.end, "%s() takes 3 components, got %d", name, len(args.values))
+	}
+	if args.legacy && name != "rgb" && name != "rgba" && name != "hsl" && name != "hsla" {
+		return color.NRGBA{}, p.errorf(args.values[0].pos, "%s() does not accept comma separated syntax", name)
+	}
+
+	alpha := 1.
+	if args.alpha != nil {
+		if alpha, err = p.number(*args.alpha, 1, true); err != nil {
+			return color.NRGBA{}, err
+		}
+	}
+
+	v := args.values
+	var c color.NRGBA
+	switch name {
+	case "rgb", "rgba":
+		c, err = p.rgb(v, args.legacy)
+	case "hsl", "hsla":
+		c, err = p.hsl(v, args.legacy)
+	case "hwb":
+		c, err = p.hwb(v)
+	case "lab":
+		c, err = p.lab(v)
+	case "lch":
+		c, err = p.lch(v)
+	case "oklab":
+		c, err = p.oklab(v)
+	case "oklch":
+		c, err = p.oklch(v)
+	case "color":
+		c, err = p.color(space, v)
+	default:
+		return color.NRGBA{}, p.errorf(start, "unknown function %q", name)
+	}
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	c.A = toUint8(alpha)
+	return c, nil
+}
+
+// number resolves a number or percentage, where 100% is equal to ref. none is resolved to 0.
+func (p *parser) number(t token, ref float64, allowNumber bool) (float64, error) {
+	switch t.kind {
+	case tokenNumber:
+		if !allowNumber {
+			return 0, p.errorf(t.pos, "expected percentage")
+		}
+		return t.value, nil
+	case tokenPercentage:
+		return t.value / 100 * ref, nil
+	case tokenIdent:
+		if t.text == "none" {
+			return 0, nil
+		}
+		return 0, p.errorf(t.pos, "unexpected %q", t.text)
+	default:
+		return 0, p.errorf(t.pos, "unexpected unit %q", t.text)
+	}
+}
+
+// hue resolves a number or angle in degrees, normalized to [0, 360).
+func (p *parser) hue(t token) (float64, error) {
+	var deg float64
+	switch t.kind {
+	case tokenNumber:
+		deg = t.value
+	case tokenDimension:
+		switch t.text {
+		case "deg":
+			deg = t.value
+		case "rad":
+			deg = t.value * 180 / math.Pi
+		case "grad":
+			deg = t.value * 0.9
+		case "turn":
+			deg = t.value * 360
+		default:
+			return 0, p.errorf(t.pos, "unknown angle unit %q", t.text)
+		}
+	case tokenIdent:
+		if t.text == "none" {
+			return 0, nil
+		}
+		return 0, p.errorf(t.pos, "unexpected %q", t.text)
+	default:
+		return 0, p.errorf(t.pos, "expected hue")
+	}
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg, nil
+}
+
+func (p *parser) numbers(ts []token, refs [3]float64, allowNumber bool) ([3]float64, error) {
+	var v [3]float64
+	for i, t := range ts {
+		n, err := p.number(t, refs[i], allowNumber)
+		if err != nil {
+			return v, err
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func (p *parser) rgb(ts []token, legacy bool) (color.NRGBA, error) {
+	if legacy {
+		for _, t := range ts[1:] {
+			if t.kind != ts[0].kind {
+				return color.NRGBA{}, p.errorf(t.pos, "cannot mix numbers and percentages in comma separated syntax")
+			}
+		}
+	}
+	v, err := p.numbers(ts, [3]float64{255, 255, 255}, true)
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	return color.NRGBA{R: toUint8(v[0] / 255), G: toUint8(v[1] / 255), B: toUint8(v[2] / 255), A: 0xff}, nil
+}
+
+func (p *parser) hsl(ts []token, legacy bool) (color.NRGBA, error) {
+	h, err := p.hue(ts[0])
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	v, err := p.numbers(ts[1:], [3]float64{100, 100}, !legacy)
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	r, g, b := hslToSRGB(h, v[0]/100, v[1]/100)
+	return fromSRGB(r, g, b), nil
+}
+
+func (p *parser) hwb(ts []token) (color.NRGBA, error) {
+	h, err := p.hue(ts[0])
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	v, err := p.numbers(ts[1:], [3]float64{100, 100}, true)
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	r, g, b := hwbToSRGB(h, v[0]/100, v[1]/100)
+	return fromSRGB(r, g, b), nil
+}
+
+func (p *parser) lab(ts []token) (color.NRGBA, error) {
+	v, err := p.numbers(ts, [3]float64{100, 125, 125}, true)
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	return fromLab(colorspace.Lab{L: v[0], A: v[1], B: v[2]}), nil
+}
+
+func (p *parser) lch(ts []token) (color.NRGBA, error) {
+	v, err := p.numbers(ts[:2], [3]float64{100, 150}, true)
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	h, err := p.hue(ts[2])
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	return fromLab(colorspace.LChToLab(colorspace.LCh{L: v[0], C: math.Max(v[1], 0), H: h})), nil
+}
+
+func (p *parser) oklab(ts []token) (color.NRGBA, error) {
+	v, err := p.numbers(ts, [3]float64{1, 0.4, 0.4}, true)
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	return colorspace.OKLabToColor(colorspace.OKLab{L: v[0], A: v[1], B: v[2]}), nil
+}
+
+func (p *parser) oklch(ts []token) (color.NRGBA, error) {
+	v, err := p.numbers(ts[:2], [3]float64{1, 0.4}, true)
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	h, err := p.hue(ts[2])
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	return colorspace.OKLabToColor(colorspace.OKLChToOKLab(colorspace.OKLCh{L: v[0], C: math.Max(v[1], 0), H: h})), nil
+}
+
+func (p *parser) color(space string, ts []token) (color.NRGBA, error) {
+	v, err := p.numbers(ts, [3]float64{1, 1, 1}, true)
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+	switch space {
+	case "srgb-linear":
+		return fromLinearSRGB(v[0], v[1], v[2]), nil
+	case "display-p3":
+		xyz := colorspace.LinearDisplayP3ToXYZ(colorspace.SRGBToLinear(v[0]), colorspace.SRGBToLinear(v[1]), colorspace.SRGBToLinear(v[2]))
+		return fromLinearSRGB(colorspace.XYZToLinearSRGB(xyz)), nil
+	default: // srgb
+		if isInUnitRange(v) {
+			return fromSRGB(v[0], v[1], v[2]), nil
+		}
+		return fromLinearSRGB(colorspace.SRGBToLinear(v[0]), colorspace.SRGBToLinear(v[1]), colorspace.SRGBToLinear(v[2])), nil
+	}
+}
+
+// fromSRGB clips gamma encoded sRGB, as the legacy sRGB functions do.
+func fromSRGB(r, g, b float64) color.NRGBA {
+	return color.NRGBA{R: toUint8(r), G: toUint8(g), B: toUint8(b), A: 0xff}
+}
+
+// fromLinearSRGB gamut maps linear sRGB that may be out of [0, 1].
+func fromLinearSRGB(r, g, b float64) color.NRGBA {
+	return colorspace.OKLabToColor(colorspace.LinearSRGBToOKLab(r, g, b))
+}
+
+// fromLab gamut maps CIELAB relative to D50, the reference white used by CSS.
+func fromLab(c colorspace.Lab) color.NRGBA {
+	xyz := colorspace.Adapt(colorspace.LabToXYZ(c, colorspace.D50), colorspace.D50, colorspace.D65)
+	return fromLinearSRGB(colorspace.XYZToLinearSRGB(xyz))
+}
+
+func hslToSRGB(h, s, l float64) (float64, float64, float64) {
+	s = clamp01(s)
+	l = clamp01(l)
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		a := s * math.Min(l, 1-l)
+		return l - a*math.Max(-1, math.Min(k-3, math.Min(9-k, 1)))
+	}
+	return f(0), f(8), f(4)
+}
+
+func hwbToSRGB(h, w, b float64) (float64, float64, float64) {
+	w = clamp01(w)
+	b = clamp01(b)
+	if w+b >= 1 {
+		gray := w / (w + b)
+		return gray, gray, gray
+	}
+	r, g, bl := hslToSRGB(h, 1, 0.5)
+	scale := 1 - w - b
+	return r*scale + w, g*scale + w, bl*scale + w
+}
+
+func isInUnitRange(v [3]float64) bool {
+	return 0 <= v[0] && v[0] <= 1 && 0 <= v[1] && v[1] <= 1 && 0 <= v[2] && v[2] <= 1
+}
+
+func toUint8(v float64) uint8 {
+	return uint8(math.Round(clamp01(v) * 255))
+}
+
+func clamp01(v float64) float64 {
+	if math.IsNaN(v) {
+		return 0
+	}
+	return math.Max(0, math.Min(1, v))
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\f'
+}
+
+func isDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9'
+}
+
+func isHexDigit(ch byte) bool {
+	return isDigit(ch) || ('a' <= ch && ch <= 'f') || ('A' <= ch && ch <= 'F')
+}
+
+func isIdentChar(ch byte) bool {
+	return ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z') || ch == '-' || ch == '_'
+}
+
+func hexValue(ch byte) uint8 {
+	switch {
+	case isDigit(ch):
+		return ch - '0'
+	case 'a' <= ch && ch <= 'f':
+		return ch - 'a' + 10
+	default:
+		return ch - 'A' + 10
+	}
+}
@@ -0,0 +1,171 @@
+package csscolor
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+)
+
+var parseTests = []struct {
+	input string
+	want  color.NRGBA
+}{
+	{"#f00", color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+	{"#f008", color.NRGBA{0xff, 0x00, 0x00, 0x88}},
+	{"#FF8000", color.NRGBA{0xff, 0x80, 0x00, 0xff}},
+	{"#ff800080", color.NRGBA{0xff, 0x80, 0x00, 0x80}},
+	{"  #00ff00  ", color.NRGBA{0x00, 0xff, 0x00, 0xff}},
+	{"red", color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+	{"RebeccaPurple", color.NRGBA{0x66, 0x33, 0x99, 0xff}},
+	{"transparent", color.NRGBA{0x00, 0x00, 0x00, 0x00}},
+	{"rgb(255, 128, 0)", color.NRGBA{0xff, 0x80, 0x00, 0xff}},
+	{"rgba(255,128,0,0.5)", color.NRGBA{0xff, 0x80, 0x00, 0x80}},
+	{"rgb(100%, 50%, 0%)", color.NRGBA{0xff, 0x80, 0x00, 0xff}},
+	{"rgb(255 128 0)", color.NRGBA{0xff, 0x80, 0x00, 0xff}},
+	{"rgb(255 128 0 / 50%)", color.NRGBA{0xff, 0x80, 0x00, 0x80}},
+	{"rgb(255 128 0 / .5)", color.NRGBA{0xff, 0x80, 0x00, 0x80}},
+	{"RGB(100% 50% 0)", color.NRGBA{0xff, 0x80, 0x00, 0xff}},
+	{"rgba(255 128 0)", color.NRGBA{0xff, 0x80, 0x00, 0xff}},
+	{"rgb(300 -10 1e2)", color.NRGBA{0xff, 0x00, 0x64, 0xff}},
+	{"rgb(none 128 0)", color.NRGBA{0x00, 0x80, 0x00, 0xff}},
+	{"hsl(120, 100%, 50%)", color.NRGBA{0x00, 0xff, 0x00, 0xff}},
+	{"hsl(120deg 100% 25%)", color.NRGBA{0x00, 0x80, 0x00, 0xff}},
+	{"hsla(0.5turn 100% 50% / 0.5)", color.NRGBA{0x00, 0xff, 0xff, 0x80}},
+	{"hsl(-120 100 50)", color.NRGBA{0x00, 0x00, 0xff, 0xff}},
+	{"hsl(3.14159265rad 100% 50%)", color.NRGBA{0x00, 0xff, 0xff, 0xff}},
+	{"hsl(200grad 100% 50%)", color.NRGBA{0x00, 0xff, 0xff, 0xff}},
+	{"hwb(0 0% 0%)", color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+	{"hwb(0 100% 100%)", color.NRGBA{0x80, 0x80, 0x80, 0xff}},
+	{"hwb(240 20% 40% / 0.25)", color.NRGBA{0x33, 0x33, 0x99, 0x40}},
+	{"lab(54.29 80.8 69.89)", color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+	{"lab(100 0 0)", color.NRGBA{0xff, 0xff, 0xff, 0xff}},
+	{"lab(0% 0% 0%)", color.NRGBA{0x00, 0x00, 0x00, 0xff}},
+	{"lch(54.29 106.84 40.85)", color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+	{"oklab(0.628 0.2249 0.1258)", color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+	{"oklch(62.8% 0.2577 29.23)", color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+	{"oklch(0.628 64.4% 29.23deg / 50%)", color.NRGBA{0xff, 0x00, 0x00, 0x80}},
+	{"oklch(1 0.1 none)", color.NRGBA{0xff, 0xff, 0xff, 0xff}},
+	{"color(srgb 1 0.5 0)", color.NRGBA{0xff, 0x80, 0x00, 0xff}},
+	{"color(srgb 100% 50% 0% / 0.5)", color.NRGBA{0xff, 0x80, 0x00, 0x80}},
+	{"color(srgb-linear 1 0 0)", color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+	{"color(display-p3 0.9175 0.2003 0.1386)", color.NRGBA{0xff, 0x00, 0x00, 0xff}},
+	{"color(display-p3 1 1 1)", color.NRGBA{0xff, 0xff, 0xff, 0xff}},
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range parseTests {
+		got, err := Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", test.input, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Parse(%q) = %v; want %v", test.input, got, test.want)
+		}
+	}
+}
+
+func TestParseNamedColors(t *testing.T) {
+	if len(namedColors) != 148 {
+		t.Errorf("len(namedColors) = %d; want 148", len(namedColors))
+	}
+	for name, want := range namedColors {
+		if got, err := Parse(name); err != nil || got != want {
+			t.Errorf("Parse(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+}
+
+func TestParseOutOfGamut(t *testing.T) {
+	tests := []string{
+		"oklch(0.7 0.4 145)",
+		"lab(50 125 -125)",
+		"lch(90 150 270)",
+		"color(display-p3 1 0 0)",
+		"color(srgb 1.5 -0.5 0.5)",
+	}
+	for _, test := range tests {
+		if _, err := Parse(test); err != nil {
+			t.Errorf("Parse(%q) returned error: %v", test, err)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{"", 0},
+		{"   ", 3},
+		{"#12", 0},
+		{"#12345g", 6},
+		{"#", 0},
+		{"notacolor", 0},
+		{"$", 0},
+		{"rgb(1 2)", 7},
+		{"rgb(1, 2 3)", 9},
+		{"rgb(1 2 3", 9},
+		{"rgb(1, 2, 3,)", 12},
+		{"rgb(1, 2%, 3)", 7},
+		{"rgb(none, 2, 3)", 4},
+		{"rgb(1 2, 3)", 7},
+		{"rgb()", 4},
+		{"rgb(1, 2, 3, 4, 5)", 16},
+		{"rgb(1 2 3deg)", 8},
+		{"rgb(1 2 3) x", 11},
+		{"hwb(0, 0%, 0%)", 4},
+		{"hsl(120, 100, 50)", 9},
+		{"hsl(1foo 50% 50%)", 4},
+		{"lab(50 20 30 / 1 2)", 13},
+		{"lab(50 20 30 /)", 13},
+		{"foo(1 2 3)", 0},
+		{"color(rec2020 1 0 0)", 6},
+		{"color(1 0 0)", 6},
+		{"rgb(1 2 foo)", 8},
+		{"rgb(1 2 -)", 8},
+		{"rgb(1 2 1e999)", 8},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.input)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("Parse(%q) error = %v; want *ParseError", test.input, err)
+			continue
+		}
+		if perr.Pos != test.pos {
+			t.Errorf("Parse(%q) error position = %d (%v); want %d", test.input, perr.Pos, perr, test.pos)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, test := range parseTests {
+		f.Add(test.input)
+	}
+	f.Add("rgb(1, 2, 3, 4, 5)")
+	f.Add("color(display-p3 1 0 0 / 10%)")
+	f.Fuzz(func(t *testing.T, s string) {
+		c, err := Parse(s)
+		if err != nil {
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("Parse(%q) error = %v; want *ParseError", s, err)
+			}
+			if perr.Pos < 0 || perr.Pos > len(s) {
+				t.Fatalf("Parse(%q) error position = %d; out of range", s, perr.Pos)
+			}
+			return
+		}
+		for _, n := range Notations {
+			f := Format(c, n)
+			got, err := Parse(f)
+			if err != nil {
+				t.Fatalf("Parse(Format(%v, %v)) = Parse(%q) returned error: %v", c, n, f, err)
+			}
+			if !roundTrips(n, c, got) {
+				t.Fatalf("Parse(Format(%v, %v)) = Parse(%q) = %v", c, n, f, got)
+			}
+		}
+	})
+}
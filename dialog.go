@@ -0,0 +1,127 @@
+package colorpicker
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+)
+
+const (
+	defaultColorDialogConfirmText = "OK"
+	defaultColorDialogDismissText = "Cancel"
+)
+
+// ColorDialog is a dialog to select a color with a picker, which can be confirmed or canceled.
+// The callback is only called with the color selected when the dialog is confirmed.
+type ColorDialog struct {
+	parent   fyne.Window
+	callback func(color.Color)
+	// preview is called with every color selected on the picker, and with the original color when canceled.
+	preview func(color.Color)
+
+	title, confirmText, dismissText string
+	pickerStyle                     PickerStyle
+	opts                            []Option
+	color                           color.Color
+
+	dialog *dialog.ConfirmDialog
+	picker ColorPicker
+}
+
+// NewColorDialog returns a dialog to select a color starting from c,
+// which calls callback with the selected color when it is confirmed.
+func NewColorDialog(title string, c color.Color, callback func(color.Color), parent fyne.Window) *ColorDialog {
+	return &ColorDialog{
+		parent:      parent,
+		callback:    callback,
+		title:       title,
+		confirmText: defaultColorDialogConfirmText,
+		dismissText: defaultColorDialogDismissText,
+		pickerStyle: StyleHue,
+		color:       c,
+	}
+}
+
+// SetTitle sets the title of the dialog shown next.
+func (d *ColorDialog) SetTitle(title string) {
+	d.title = title
+}
+
+// SetConfirmText sets the label of the confirm button of the dialog shown next. The default is "OK".
+func (d *ColorDialog) SetConfirmText(label string) {
+	d.confirmText = label
+}
+
+// SetDismissText sets the label of the cancel button of the dialog shown next. The default is "Cancel".
+func (d *ColorDialog) SetDismissText(label string) {
+	d.dismissText = label
+}
+
+// SetPickerStyle sets the style of the picker of the dialog shown next.
+func (d *ColorDialog) SetPickerStyle(s PickerStyle) {
+	d.pickerStyle = s
+}
+
+// SetPickerOptions sets the options of the picker of the dialog shown next.
+// The initial color is always the color of the dialog.
+func (d *ColorDialog) SetPickerOptions(opts ...Option) {
+	d.opts = opts
+}
+
+// SetOnPreview enables the live preview, in which f is called with every color selected on the picker,
+// and with the original color when the dialog is canceled, so that the preview can be reverted.
+func (d *ColorDialog) SetOnPreview(f func(color.Color)) {
+	d.preview = f
+}
+
+// Color returns the color the dialog starts from, which is the selected color once it is confirmed.
+func (d *ColorDialog) Color() color.Color {
+	return d.color
+}
+
+// SetColor sets the color the dialog starts from.
+func (d *ColorDialog) SetColor(c color.Color) {
+	d.color = c
+}
+
+// Show shows the dialog with a new picker.
+func (d *ColorDialog) Show() {
+	opts := append(d.opts[:len(d.opts):len(d.opts)], WithInitialColor(d.color))
+	d.picker = NewWithOptions(d.pickerStyle, opts...)
+	if d.preview != nil {
+		d.picker.SetOnChanged(d.preview)
+	}
+	content := container.NewVBox(d.picker, NewCompareSwatch(d.picker))
+	d.dialog = dialog.NewCustomConfirm(d.title, d.confirmText, d.dismissText, content, d.closed, d.parent)
+	d.dialog.Show()
+}
+
+// Confirm closes the dialog, calling the callback with the selected color.
+func (d *ColorDialog) Confirm() {
+	if d.dialog != nil {
+		d.dialog.Confirm()
+	}
+}
+
+// Dismiss closes the dialog without changing the color, reverting the preview.
+func (d *ColorDialog) Dismiss() {
+	if d.dialog != nil {
+		d.dialog.Dismiss()
+	}
+}
+
+func (d *ColorDialog) closed(confirmed bool) {
+	d.dialog = nil
+	if !confirmed {
+		if d.preview != nil && !colorEquals(d.picker.Color(), d.color) {
+			d.preview(d.color)
+		}
+		return
+	}
+	d.color = d.picker.Color()
+	if d.callback != nil {
+		d.callback(d.color)
+	}
+}
@@ -0,0 +1,172 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestColorDialog(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 400))
+	original := color.NRGBA{R: 0xff, A: 0xff}
+	selected := color.NRGBA{B: 0xff, A: 0xff}
+
+	var confirmed []color.Color
+	d := NewColorDialog("Pick", original, func(c color.Color) { confirmed = append(confirmed, c) }, w)
+	d.SetConfirmText("Apply")
+	d.SetDismissText("Discard")
+	d.Show()
+	if got := findButtonTexts(w.Canvas().Overlays().Top()); len(got) != 2 || got[0] != "Discard" || got[1] != "Apply" {
+		t.Errorf("buttons = %v; want [Discard Apply]", got)
+	}
+	d.picker.SetColor(selected)
+	d.Dismiss()
+	if len(confirmed) != 0 || !colorEquals(d.Color(), original) {
+		t.Errorf("confirmed %v and Color() = %v after Dismiss; want none and %v", confirmed, d.Color(), original)
+	}
+
+	d.Show()
+	if got := d.picker.Color(); !colorEquals(got, original) {
+		t.Errorf("picker color = %v after reopening; want %v", got, original)
+	}
+	d.picker.SetColor(color.Black)
+	d.picker.SetColor(selected)
+	d.Confirm()
+	if len(confirmed) != 1 || !colorEquals(confirmed[0], selected) || !colorEquals(d.Color(), selected) {
+		t.Errorf("confirmed %v and Color() = %v after Confirm; want only %v", confirmed, d.Color(), selected)
+	}
+}
+
+func TestColorDialogPreview(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	original := color.NRGBA{R: 0xff, A: 0xff}
+
+	var previewed []color.Color
+	d := NewColorDialog("Pick", original, nil, w)
+	d.SetOnPreview(func(c color.Color) { previewed = append(previewed, c) })
+	d.Show()
+	d.Dismiss()
+	if len(previewed) != 0 {
+		t.Errorf("previewed %v after Dismiss without changes; want none", previewed)
+	}
+
+	d.Show()
+	d.picker.SetColor(color.Black)
+	d.Dismiss()
+	if len(previewed) != 2 || !colorEquals(previewed[0], color.Black) || !colorEquals(previewed[1], original) {
+		t.Errorf("previewed %v; want black and then %v", previewed, original)
+	}
+}
+
+func TestColorSelectModalRectCancel(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 400))
+	original := color.NRGBA{R: 0xff, A: 0xff}
+	rect := NewColorSelectModalRect(w, fyne.NewSize(20, 20), original).(*colorSelectModalRect)
+	w.SetContent(rect)
+	var changed []color.Color
+	rect.SetOnChange(func(c color.Color) { changed = append(changed, c) })
+	committed := 0
+	rect.addCommitListener(func(_, _ color.Color) { committed++ })
+
+	test.Tap(rect)
+	findCompareSwatch(w.Canvas().Overlays().Top()).picker.SetColor(color.Black)
+	if got := rect.color(); !colorEquals(got, color.Black) {
+		t.Errorf("rect color = %v while previewing; want black", got)
+	}
+	tapButton(t, w.Canvas().Overlays().Top(), "Cancel")
+	if got := rect.color(); !colorEquals(got, original) {
+		t.Errorf("rect color = %v after Cancel; want %v", got, original)
+	}
+	if len(changed) != 2 || !colorEquals(changed[1], original) || committed != 0 {
+		t.Errorf("changed %v and committed %d times; want reverted to %v without commit", changed, committed, original)
+	}
+
+	test.Tap(rect)
+	findCompareSwatch(w.Canvas().Overlays().Top()).picker.SetColor(color.Black)
+	tapButton(t, w.Canvas().Overlays().Top(), "OK")
+	if got := rect.color(); !colorEquals(got, color.Black) || committed != 1 {
+		t.Errorf("rect color = %v and committed %d times after OK; want black once", got, committed)
+	}
+}
+
+func findButtons(o fyne.CanvasObject) []*widget.Button {
+	switch o := o.(type) {
+	case *widget.Button:
+		return []*widget.Button{o}
+	case *fyne.Container:
+		var buttons []*widget.Button
+		for _, child := range o.Objects {
+			buttons = append(buttons, findButtons(child)...)
+		}
+		return buttons
+	case fyne.Widget:
+		var buttons []*widget.Button
+		for _, child := range test.WidgetRenderer(o).Objects() {
+			buttons = append(buttons, findButtons(child)...)
+		}
+		return buttons
+	}
+	return nil
+}
+
+func findButtonTexts(o fyne.CanvasObject) []string {
+	var texts []string
+	for _, b := range findButtons(o) {
+		texts = append(texts, b.Text)
+	}
+	return texts
+}
+
+func tapButton(t *testing.T, o fyne.CanvasObject, text string) {
+	t.Helper()
+	for _, b := range findButtons(o) {
+		if b.Text == text {
+			test.Tap(b)
+			return
+		}
+	}
+	t.Fatalf("no button %q", text)
+}
+
+func TestColorSelectModalRectDisable(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 400))
+	for _, rect := range []PickerOpenWidget{
+		NewColorSelectModalRect(w, fyne.NewSize(20, 20), color.White),
+		NewColorSelectPopUpRect(w, fyne.NewSize(20, 20), color.White),
+	} {
+		w.SetContent(rect)
+		rect.Disable()
+		if !rect.Disabled() {
+			t.Error("Disabled() = false after Disable")
+		}
+		test.Tap(rect.(fyne.Tappable))
+		if w.Canvas().Overlays().Top() != nil {
+			t.Error("the picker is opened while disabled")
+		}
+		if c := rect.(desktop.Cursorable).Cursor(); c != desktop.DefaultCursor {
+			t.Errorf("Cursor() = %v while disabled; want the default cursor", c)
+		}
+
+		rect.Enable()
+		test.Tap(rect.(fyne.Tappable))
+		if w.Canvas().Overlays().Top() == nil {
+			t.Error("the picker is not opened after Enable")
+		}
+		w.Canvas().Overlays().Remove(w.Canvas().Overlays().Top())
+	}
+}
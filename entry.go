@@ -0,0 +1,78 @@
+package colorpicker
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2/widget"
+	"github.com/lusingander/colorpicker/csscolor"
+)
+
+// changeListenable is implemented by the pickers of this package.
+type changeListenable interface {
+	addChangeListener(func(color.Color))
+}
+
+// ColorEntry is a text entry bound to a ColorPicker, which displays its color as a CSS color string.
+// The typed or pasted text is validated as it changes, and sets the color of the picker
+// when it is submitted or the entry loses focus.
+type ColorEntry struct {
+	widget.Entry
+
+	picker   ColorPicker
+	notation csscolor.Notation
+}
+
+// NewColorEntry returns a text entry bound to picker, which displays the color in notation n.
+// Colors set on picker by other means are only displayed if picker was created by this package.
+func NewColorEntry(picker ColorPicker, n csscolor.Notation) *ColorEntry {
+	e := &ColorEntry{
+		picker:   picker,
+		notation: n,
+	}
+	e.ExtendBaseWidget(e)
+	e.Validator = func(s string) error {
+		_, err := csscolor.Parse(s)
+		return err
+	}
+	e.AlwaysShowValidationError = true
+	e.OnSubmitted = func(string) {
+		e.apply()
+	}
+	if l, ok := picker.(changeListenable); ok {
+		l.addChangeListener(e.update)
+	}
+	e.update(picker.Color())
+	return e
+}
+
+// Notation returns the notation used to display the color.
+func (e *ColorEntry) Notation() csscolor.Notation {
+	return e.notation
+}
+
+// SetNotation sets the notation used to display the color.
+func (e *ColorEntry) SetNotation(n csscolor.Notation) {
+	e.notation = n
+	e.update(e.picker.Color())
+}
+
+// FocusLost applies the text to the picker and reformats it in the notation of the entry, discarding invalid input.
+func (e *ColorEntry) FocusLost() {
+	e.Entry.FocusLost()
+	e.apply()
+}
+
+// apply sets the color of the text to the picker if it is valid and differs from the color of the picker,
+// and replaces the text with the color of the picker.
+func (e *ColorEntry) apply() {
+	if c, err := csscolor.Parse(e.Text); err == nil && c != color.NRGBAModel.Convert(e.picker.Color()) {
+		e.picker.SetColor(c)
+	}
+	e.update(e.picker.Color())
+}
+
+func (e *ColorEntry) update(c color.Color) {
+	if s := csscolor.Format(c, e.notation); s != e.Text {
+		e.SetText(s)
+	}
+}
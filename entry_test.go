@@ -0,0 +1,114 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/lusingander/colorpicker/csscolor"
+)
+
+func TestColorEntry(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := New(200, style)
+		var changed []color.Color
+		p.SetOnChanged(func(c color.Color) {
+			changed = append(changed, c)
+		})
+		e := NewColorEntry(p, csscolor.NotationHex)
+		if e.Text != "#ffffff" {
+			t.Errorf("style %d: Text = %q; want %q", style, e.Text, "#ffffff")
+		}
+
+		e.SetText("")
+		test.Type(e, "#00ff0")
+		if e.Text != "#00ff0" {
+			t.Errorf("style %d: Text while typing = %q; want %q", style, e.Text, "#00ff0")
+		}
+		if e.Validate() == nil {
+			t.Errorf("style %d: Validate() = nil for %q", style, e.Text)
+		}
+		// the valid colors on the way, such as "#00f" and "#00ff", are not applied
+		if len(changed) != 0 {
+			t.Errorf("style %d: changed %d times while typing; want none", style, len(changed))
+		}
+
+		test.Type(e, "0")
+		want := color.NRGBA{0x00, 0xff, 0x00, 0xff}
+		if e.Text != "#00ff00" || e.Validate() != nil {
+			t.Errorf("style %d: Text = %q, Validate() = %v", style, e.Text, e.Validate())
+		}
+		if len(changed) != 0 {
+			t.Errorf("style %d: changed %d times before submit; want none", style, len(changed))
+		}
+		e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+		if len(changed) != 1 || changed[0] != want {
+			t.Errorf("style %d: changed with %v; want [%v]", style, changed, want)
+		}
+		if got := p.Color(); got != want {
+			t.Errorf("style %d: Color() = %v; want %v", style, got, want)
+		}
+
+		changed = nil
+		e.SetNotation(csscolor.NotationRGB)
+		if e.Text != "rgb(0 255 0)" {
+			t.Errorf("style %d: Text = %q; want %q", style, e.Text, "rgb(0 255 0)")
+		}
+		p.SetColor(color.NRGBA{0xff, 0x00, 0x00, 0x80})
+		if e.Text != "rgb(255 0 0 / 0.502)" {
+			t.Errorf("style %d: Text = %q; want %q", style, e.Text, "rgb(255 0 0 / 0.502)")
+		}
+		if len(changed) != 1 {
+			t.Errorf("style %d: changed %d times; want 1", style, len(changed))
+		}
+	}
+}
+
+func TestColorEntryFocusLost(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue)
+	e := NewColorEntry(p, csscolor.NotationHex)
+	w := test.NewWindow(e)
+	defer w.Close()
+
+	w.Canvas().Focus(e)
+	e.SetText("")
+	test.Type(e, "red")
+	if e.Text != "red" {
+		t.Errorf("Text while typing = %q; want %q", e.Text, "red")
+	}
+	if got := p.Color(); !colorEquals(got, color.White) {
+		t.Errorf("Color() while typing = %v; want white", got)
+	}
+	w.Canvas().Unfocus()
+	if e.Text != "#ff0000" {
+		t.Errorf("Text after focus lost = %q; want %q", e.Text, "#ff0000")
+	}
+	if got := p.Color(); !colorEquals(got, color.NRGBA{R: 0xff, A: 0xff}) {
+		t.Errorf("Color() after focus lost = %v; want red", got)
+	}
+
+	w.Canvas().Focus(e)
+	test.Type(e, "zz")
+	w.Canvas().Unfocus()
+	if e.Text != "#ff0000" {
+		t.Errorf("Text after invalid input = %q; want %q", e.Text, "#ff0000")
+	}
+}
+
+func TestColorEntrySubmit(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue)
+	e := NewColorEntry(p, csscolor.NotationHex)
+	e.SetText("")
+	test.Type(e, "hsl(240 100% 50%)")
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	if e.Text != "#0000ff" {
+		t.Errorf("Text after submit = %q; want %q", e.Text, "#0000ff")
+	}
+	if got := p.Color(); !colorEquals(got, color.NRGBA{B: 0xff, A: 0xff}) {
+		t.Errorf("Color() after submit = %v; want blue", got)
+	}
+}
@@ -0,0 +1,126 @@
+package colorpicker
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// defaultHistoryLimit is the number of colors that can be undone if the limit is not positive.
+const defaultHistoryLimit = 100
+
+// commitListenable is implemented by the pickers and the picker-open widgets of this package.
+type commitListenable interface {
+	addCommitListener(func(from, to color.Color))
+}
+
+// historyRecordable is implemented by the pickers of this package.
+type historyRecordable interface {
+	commitListenable
+	addShortcutHandler(func(fyne.Shortcut) bool)
+}
+
+// History records the colors committed on a ColorPicker, so that the changes can be undone and redone.
+// While the picker has focus, Ctrl+Z undoes and Ctrl+Shift+Z or Ctrl+Y redoes, or Cmd on macOS.
+type History struct {
+	picker ColorPicker
+	limit  int
+
+	// colors are the recorded colors from the oldest, and index is the position of the current color.
+	colors []color.Color
+	index  int
+
+	changed func()
+}
+
+// NewHistory returns a history of the colors committed on picker, which keeps up to limit colors to undo.
+// If limit is not positive, it keeps 100 colors. Only pickers created by this package are recorded.
+func NewHistory(picker ColorPicker, limit int) *History {
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	h := &History{
+		picker:  picker,
+		limit:   limit,
+		colors:  []color.Color{picker.Color()},
+		changed: func() {},
+	}
+	if r, ok := picker.(historyRecordable); ok {
+		r.addCommitListener(h.record)
+		r.addShortcutHandler(h.typedShortcut)
+	}
+	return h
+}
+
+// SetOnChanged sets the function called when colors are recorded, undone or redone,
+// which is where CanUndo and CanRedo may change.
+func (h *History) SetOnChanged(f func()) {
+	h.changed = f
+}
+
+// CanUndo reports whether there is a color to go back to.
+func (h *History) CanUndo() bool {
+	return h.index > 0
+}
+
+// CanRedo reports whether there is an undone color to go forward to.
+func (h *History) CanRedo() bool {
+	return h.index < len(h.colors)-1
+}
+
+// Undo sets the previous color on the picker, if any.
+func (h *History) Undo() {
+	if !h.CanUndo() {
+		return
+	}
+	h.index--
+	h.apply()
+}
+
+// Redo sets the color undone last on the picker, if any.
+func (h *History) Redo() {
+	if !h.CanRedo() {
+		return
+	}
+	h.index++
+	h.apply()
+}
+
+func (h *History) apply() {
+	h.picker.SetColor(h.colors[h.index])
+	h.changed()
+}
+
+// record appends the committed color to the history, discarding the undone colors.
+// The color from is recorded first if the color was set on the picker by other means since the last record.
+func (h *History) record(from, to color.Color) {
+	h.colors = h.colors[:h.index+1]
+	if !colorEquals(from, h.colors[h.index]) {
+		h.colors = append(h.colors, from)
+	}
+	h.colors = append(h.colors, to)
+	if n := len(h.colors) - (h.limit + 1); n > 0 {
+		h.colors = h.colors[n:]
+	}
+	h.index = len(h.colors) - 1
+	h.changed()
+}
+
+// typedShortcut undoes or redoes by the shortcut s, and reports whether s is one of them.
+func (h *History) typedShortcut(s fyne.Shortcut) bool {
+	switch s := s.(type) {
+	case *fyne.ShortcutUndo:
+		h.Undo()
+		return true
+	case *fyne.ShortcutRedo:
+		h.Redo()
+		return true
+	case *desktop.CustomShortcut:
+		if s.KeyName == fyne.KeyZ && s.Modifier == fyne.KeyModifierShortcutDefault|fyne.KeyModifierShift {
+			h.Redo()
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestHistory(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue).(*defaultHueColorPicker)
+	p.SetHSVA(0, 1, 1, 1)
+	first := p.Color()
+	h := NewHistory(p, 0)
+	changed := 0
+	h.SetOnChanged(func() { changed++ })
+	if h.CanUndo() || h.CanRedo() {
+		t.Fatal("CanUndo() or CanRedo() is true before any change")
+	}
+
+	test.TapAt(p.hueBar.raster, fyne.NewPos(5, 50))
+	second := p.Color()
+	// a color set by other means is recorded before the next committed color
+	p.SetColor(color.NRGBA{R: 0x33, G: 0x66, B: 0x99, A: 0xff})
+	third := p.Color()
+	test.TapAt(p.alphaPickerBar.raster, fyne.NewPos(5, 50))
+	fourth := p.Color()
+	if changed != 2 {
+		t.Errorf("changed %d times; want 2", changed)
+	}
+
+	for _, want := range []color.Color{third, second, first} {
+		h.Undo()
+		if got := p.Color(); !colorEquals(got, want) {
+			t.Errorf("Color() = %v after Undo; want %v", got, want)
+		}
+	}
+	if h.CanUndo() || !h.CanRedo() {
+		t.Errorf("CanUndo() = %v, CanRedo() = %v; want false, true", h.CanUndo(), h.CanRedo())
+	}
+	h.Redo()
+	if got := p.Color(); !colorEquals(got, second) {
+		t.Errorf("Color() = %v after Redo; want %v", got, second)
+	}
+
+	// a new change discards the undone colors
+	test.TapAt(p.hueBar.raster, fyne.NewPos(5, 20))
+	if h.CanRedo() {
+		t.Error("CanRedo() is true after a new change")
+	}
+	h.Undo()
+	if got := p.Color(); !colorEquals(got, second) || colorEquals(got, fourth) {
+		t.Errorf("Color() = %v after Undo; want %v", got, second)
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue).(*defaultHueColorPicker)
+	p.SetHSVA(0, 1, 1, 1)
+	h := NewHistory(p, 2)
+	for i := 1; i <= 5; i++ {
+		test.TapAt(p.hueBar.raster, fyne.NewPos(5, float32(i*10)))
+	}
+	undone := 0
+	for h.CanUndo() {
+		h.Undo()
+		undone++
+	}
+	if undone != 2 {
+		t.Errorf("undone %d times; want 2", undone)
+	}
+	if got, want := p.hueBar.selected, 0.15; notEquals(got, want) {
+		t.Errorf("hue = %f; want %f", got, want)
+	}
+}
+
+func TestHistoryShortcuts(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue).(*defaultHueColorPicker)
+	p.SetHSVA(0, 1, 1, 1)
+	first := p.Color()
+	h := NewHistory(p, 0)
+	test.TapAt(p.hueBar.raster, fyne.NewPos(5, 50))
+	second := p.Color()
+
+	p.colorPickerRaster.TypedShortcut(&fyne.ShortcutUndo{})
+	if got := p.Color(); !colorEquals(got, first) {
+		t.Errorf("Color() = %v after Ctrl+Z; want %v", got, first)
+	}
+	p.hueBar.raster.TypedShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyZ,
+		Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift,
+	})
+	if got := p.Color(); !colorEquals(got, second) {
+		t.Errorf("Color() = %v after Ctrl+Shift+Z; want %v", got, second)
+	}
+	p.alphaPickerBar.raster.TypedShortcut(&fyne.ShortcutUndo{})
+	p.alphaPickerBar.raster.TypedShortcut(&fyne.ShortcutRedo{})
+	if got := p.Color(); !colorEquals(got, second) || h.CanRedo() {
+		t.Errorf("Color() = %v after Ctrl+Z and Ctrl+Y; want %v", got, second)
+	}
+}
+
+func TestPickerPassesShortcuts(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue).(*defaultHueColorPicker)
+	w := test.NewWindow(p)
+	defer w.Close()
+	var typed []fyne.Shortcut
+	save := &desktop.CustomShortcut{KeyName: fyne.KeyS, Modifier: fyne.KeyModifierShortcutDefault}
+	w.Canvas().AddShortcut(save, func(s fyne.Shortcut) { typed = append(typed, s) })
+	w.Canvas().AddShortcut(&fyne.ShortcutUndo{}, func(s fyne.Shortcut) { typed = append(typed, s) })
+
+	// the shortcuts reach the window unless a history is attached
+	p.colorPickerRaster.TypedShortcut(save)
+	p.colorPickerRaster.TypedShortcut(&fyne.ShortcutUndo{})
+	if len(typed) != 2 {
+		t.Errorf("window received %d shortcuts; want 2", len(typed))
+	}
+
+	typed = nil
+	NewHistory(p, 0)
+	p.colorPickerRaster.TypedShortcut(save)
+	p.colorPickerRaster.TypedShortcut(&fyne.ShortcutUndo{})
+	if len(typed) != 1 || typed[0] != save {
+		t.Errorf("window received %v; want only the save shortcut", typed)
+	}
+}
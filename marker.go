@@ -18,17 +18,10 @@ type marker interface {
 
 	position() fyne.Position
 	setPosition(fyne.Position)
+	setStyle(MarkerStyle)
 	object() fyne.CanvasObject
 }
 
-func setPositionX(m marker, x float32) {
-	m.setPosition(fyne.NewPos(x, m.position().Y))
-}
-
-func setPositionY(m marker, y float32) {
-	m.setPosition(fyne.NewPos(m.position().X, y))
-}
-
 type defaultMarker struct {
 	*canvas.Circle
 	center fyne.Position
@@ -48,6 +41,13 @@ func newDefaultMarker(radius float32) marker {
 	return marker
 }
 
+// newStyledMarker returns a marker of the radius and colors of s.
+func newStyledMarker(s MarkerStyle) marker {
+	m := newDefaultMarker(s.Radius)
+	m.setStyle(s)
+	return m
+}
+
 func (m *defaultMarker) position() fyne.Position {
 	return m.center
 }
@@ -58,6 +58,13 @@ func (m *defaultMarker) setPosition(p fyne.Position) {
 	m.Position2 = fyne.NewPos(p.X+float32(m.radius), p.Y+float32(m.radius))
 }
 
+// setStyle sets the colors of s. The radius is not changed.
+func (m *defaultMarker) setStyle(s MarkerStyle) {
+	m.FillColor = s.FillColor
+	m.StrokeColor = s.StrokeColor
+	m.StrokeWidth = s.StrokeWidth
+}
+
 func (m *defaultMarker) object() fyne.CanvasObject {
 	return m.Circle
 }
@@ -67,15 +74,22 @@ type barMarker interface {
 
 	setPositionFromValue(v float32)
 	calcValueFromPosition(p fyne.Position) float32
+	// setBarSize fits the marker to the bar of size, which is barWidth across.
+	setBarSize(size fyne.Size, barWidth float32)
 }
 
 type defaultBarMarker struct {
-	marker
+	*defaultMarker
+}
+
+func newDefaultBarMarker(s MarkerStyle) barMarker {
+	m := newDefaultMarker(0)
+	m.setStyle(s)
+	return &defaultBarMarker{defaultMarker: m.(*defaultMarker)}
 }
 
-func newDefaultBarMarker(barWidth float32) barMarker {
-	m := newDefaultMarker(barWidth / 2)
-	return &defaultBarMarker{marker: m}
+func (m *defaultBarMarker) setBarSize(_ fyne.Size, barWidth float32) {
+	m.radius = barWidth / 2
 }
 
 func (m *defaultBarMarker) setPositionFromValue(v float32) {
@@ -91,18 +105,21 @@ type circleBarMarker struct {
 	cx, cy float32
 }
 
-func newCircleBarMarker(w, h float32, barWidth float32) *circleBarMarker {
-	fw := float64(w)
-	fh := float64(h)
-	fr := barWidth / 2
-	marker := &circleBarMarker{
-		defaultMarker: newDefaultMarker(fr).(*defaultMarker),
-		cx:            w / 2,
-		cy:            h / 2,
+func newCircleBarMarker(s MarkerStyle) *circleBarMarker {
+	m := newDefaultMarker(0)
+	m.setStyle(s)
+	return &circleBarMarker{
+		defaultMarker: m.(*defaultMarker),
 	}
-	markerCenter := fyne.NewPos(float32(math.Round(fw-float64(fr))), float32(math.Round(fh/2)))
-	marker.defaultMarker.setPosition(markerCenter)
-	return marker
+}
+
+func (m *circleBarMarker) setBarSize(size fyne.Size, barWidth float32) {
+	fr := barWidth / 2
+	m.radius = fr
+	m.cx = size.Width / 2
+	m.cy = size.Height / 2
+	markerCenter := fyne.NewPos(float32(math.Round(float64(size.Width-fr))), float32(math.Round(float64(size.Height/2))))
+	m.defaultMarker.setPosition(markerCenter)
 }
 
 func (m *circleBarMarker) setPosition(p fyne.Position) {
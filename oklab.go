@@ -0,0 +1,31 @@
+package colorpicker
+
+import (
+	"image/color"
+
+	"github.com/lusingander/colorpicker/colorspace"
+)
+
+const (
+	// oklchMaxChroma is the chroma treated as 100% in CSS Color 4, enough to cover sRGB.
+	oklchMaxChroma = 0.4
+	// oklchAchromaticChroma is the chroma below which the hue is considered undefined.
+	oklchAchromaticChroma = 0.0001
+	// oklchAchromaticLightness is the distance from 0 or 1 within which the lightness is considered black or white,
+	// whose chroma is undefined.
+	oklchAchromaticLightness = 0.0001
+)
+
+// toOKLCH converts c to OKLCH. Hue is in the range [0, 1].
+func toOKLCH(c color.Color) (l, ch, h, a float64) {
+	_, _, _, a = toFloatRGBA(c)
+	lch := colorspace.OKLabToOKLCh(colorspace.ColorToOKLab(c))
+	return lch.L, lch.C, lch.H / 360, a
+}
+
+// fromOKLCHA returns the sRGB color for OKLCH, mapping it into the sRGB gamut if necessary. Hue is in the range [0, 1].
+func fromOKLCHA(l, c, h, a float64) color.NRGBA {
+	rgba := colorspace.OKLabToColor(colorspace.OKLChToOKLab(colorspace.OKLCh{L: l, C: c, H: h * 360}))
+	rgba.A = roundUint8(a * 255)
+	return rgba
+}
@@ -0,0 +1,41 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestToOKLCH(t *testing.T) {
+	tests := []struct {
+		c        color.Color
+		l, c2, h float64
+	}{
+		{color.NRGBA{0xff, 0xff, 0xff, 0xff}, 1., 0., -1},
+		{color.NRGBA{0x00, 0x00, 0x00, 0xff}, 0., 0., -1},
+		{color.NRGBA{0xff, 0x00, 0x00, 0xff}, 0.627955, 0.257683, 29.2339 / 360.},
+		{color.NRGBA{0x00, 0xff, 0x00, 0xff}, 0.866440, 0.294827, 142.4953 / 360.},
+		{color.NRGBA{0x00, 0x00, 0xff, 0xff}, 0.452014, 0.313214, 264.0521 / 360.},
+	}
+	for _, test := range tests {
+		l, c, h, _ := toOKLCH(test.c)
+		if notEquals(test.l, l) || notEquals(test.c2, c) || (test.h >= 0 && notEquals(test.h, h)) {
+			t.Errorf("toOKLCH(%v) = %f, %f, %f; want %f, %f, %f",
+				test.c, l, c, h, test.l, test.c2, test.h)
+		}
+	}
+}
+
+func TestToOKLCHAndFromOKLCHA(t *testing.T) {
+	for r := 0; r <= 255; r += 3 {
+		for g := 0; g <= 255; g += 3 {
+			for b := 0; b <= 255; b += 3 {
+				want := color.NRGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 0xff}
+				l, c, h, a := toOKLCH(want)
+				got := fromOKLCHA(l, c, h, a)
+				if want != got {
+					t.Errorf("fromOKLCHA(toOKLCH(%v)) = %v", want, got)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package colorpicker
+
+import (
+	"image/color"
+)
+
+const (
+	defaultPickerSize = 200
+	// defaultBarWidthRatio is the width of the bars relative to the height of the picker.
+	defaultBarWidthRatio = 0.1
+)
+
+var (
+	defaultMarkerStyle = MarkerStyle{
+		Radius:      5,
+		FillColor:   markerFillColor,
+		StrokeColor: markerStrokeColor,
+		StrokeWidth: 1,
+	}
+	defaultCheckerboardColors = [2]color.Color{
+		color.NRGBA{R: 58, G: 58, B: 58, A: 0xff},
+		color.NRGBA{R: 84, G: 84, B: 84, A: 0xff},
+	}
+)
+
+// MarkerStyle represents how the markers showing the selected color are displayed.
+type MarkerStyle struct {
+	// Radius is the radius of the marker on the area. The markers on the bars fit the width of the bars.
+	Radius      float32
+	FillColor   color.Color
+	StrokeColor color.Color
+	StrokeWidth float32
+}
+
+// Option configures a picker created by NewWithOptions.
+type Option func(*options)
+
+type options struct {
+	size               float32
+	barWidthRatio      float32
+	alpha              bool
+	opaqueBackground   color.Color
+	orientation        Orientation
+	initialColor       color.Color
+	markerStyle        MarkerStyle
+	checkerboardColors [2]color.Color
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		size:               defaultPickerSize,
+		barWidthRatio:      defaultBarWidthRatio,
+		alpha:              true,
+		markerStyle:        defaultMarkerStyle,
+		checkerboardColors: defaultCheckerboardColors,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithSize sets the minimum height of the picker. The default is 200.
+func WithSize(size float32) Option {
+	return func(o *options) {
+		o.size = size
+	}
+}
+
+// WithBarWidthRatio sets the width of the bars relative to the height of the picker. The default is 0.1.
+func WithBarWidthRatio(ratio float32) Option {
+	return func(o *options) {
+		o.barWidthRatio = ratio
+	}
+}
+
+// WithoutAlpha removes the alpha bar from the picker.
+func WithoutAlpha() Option {
+	return func(o *options) {
+		o.alpha = false
+	}
+}
+
+// WithOrientation sets how the bars are laid out against the area. The default is OrientationVertical.
+// In OrientationHorizontal, the size set by WithSize is the minimum width of the picker.
+func WithOrientation(orientation Orientation) Option {
+	return func(o *options) {
+		o.orientation = orientation
+	}
+}
+
+// WithOpaque removes the alpha bar from the picker and keeps the color fully opaque.
+// Colors with alpha set on the picker are flattened against background, or white if background is nil.
+func WithOpaque(background color.Color) Option {
+	return func(o *options) {
+		if background == nil {
+			background = color.White
+		}
+		o.alpha = false
+		o.opaqueBackground = background
+	}
+}
+
+// WithInitialColor sets the color selected when the picker is created. The default is white.
+func WithInitialColor(c color.Color) Option {
+	return func(o *options) {
+		o.initialColor = c
+	}
+}
+
+// WithMarkerStyle sets how the markers are displayed.
+func WithMarkerStyle(s MarkerStyle) Option {
+	return func(o *options) {
+		o.markerStyle = s
+	}
+}
+
+// WithCheckerboardColors sets the colors of the checkerboard displayed behind the alpha bar.
+func WithCheckerboardColors(c1, c2 color.Color) Option {
+	return func(o *options) {
+		o.checkerboardColors = [2]color.Color{c1, c2}
+	}
+}
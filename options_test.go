@@ -0,0 +1,115 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestNewWithOptionsDefaults(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		if got, want := NewWithOptions(style).MinSize(), New(defaultPickerSize, style).MinSize(); got != want {
+			t.Errorf("style %d: MinSize() = %v; want %v", style, got, want)
+		}
+	}
+}
+
+func TestNewWithOptionsSizeAndBarWidth(t *testing.T) {
+	test.NewTempApp(t)
+	p := NewWithOptions(StyleHue, WithSize(100), WithBarWidthRatio(0.25)).(*defaultHueColorPicker)
+
+	want := fyne.NewSize(100+25+25+theme.Padding()*2, 100)
+	if got := p.MinSize(); got != want {
+		t.Errorf("MinSize() = %v; want %v", got, want)
+	}
+	if got := p.hueBar.raster.Size(); got != fyne.NewSize(25, 100) {
+		t.Errorf("hue bar size = %v; want 25x100", got)
+	}
+}
+
+func TestNewWithOptionsWithoutAlpha(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		with := New(100, style)
+		without := NewWithOptions(style, WithSize(100), WithoutAlpha())
+
+		want := with.MinSize().Width - 10 - theme.Padding()
+		if got := without.MinSize().Width; got != want {
+			t.Errorf("style %d: MinSize().Width = %v; want %v", style, got, want)
+		}
+	}
+}
+
+func TestNewWithOptionsInitialColor(t *testing.T) {
+	test.NewTempApp(t)
+	c := color.NRGBA{R: 0x33, G: 0x66, B: 0x99, A: 0xff}
+	for _, style := range allStyles {
+		p := NewWithOptions(style, WithInitialColor(c))
+		if got := color.NRGBAModel.Convert(p.Color()); got != c {
+			t.Errorf("style %d: Color() = %v; want %v", style, got, c)
+		}
+	}
+}
+
+func TestNewWithOptionsMarkerStyle(t *testing.T) {
+	test.NewTempApp(t)
+	s := MarkerStyle{
+		Radius:      8,
+		FillColor:   color.White,
+		StrokeColor: color.Black,
+		StrokeWidth: 2,
+	}
+	p := NewWithOptions(StyleHue, WithMarkerStyle(s)).(*defaultHueColorPicker)
+
+	m := p.colorMarker.(*defaultMarker)
+	if m.radius != 8 || m.FillColor != s.FillColor || m.StrokeColor != s.StrokeColor || m.StrokeWidth != 2 {
+		t.Errorf("color marker = %v, %v, %v, %v; want %v", m.radius, m.FillColor, m.StrokeColor, m.StrokeWidth, s)
+	}
+	if b := p.hueBar.marker.(*defaultBarMarker); b.FillColor != s.FillColor || b.StrokeWidth != 2 {
+		t.Errorf("hue marker = %v, %v; want %v", b.FillColor, b.StrokeWidth, s)
+	}
+}
+
+func TestNewWithOptionsCheckerboardColors(t *testing.T) {
+	test.NewTempApp(t)
+	c1, c2 := color.NRGBA{R: 0xff, A: 0xff}, color.NRGBA{B: 0xff, A: 0xff}
+	p := NewWithOptions(StyleHue, WithCheckerboardColors(c1, c2)).(*defaultHueColorPicker)
+
+	if got := p.alphaPickerBar.checkerboardColors; got != [2]color.Color{c1, c2} {
+		t.Errorf("checkerboardColors = %v; want %v, %v", got, c1, c2)
+	}
+}
+
+func TestNewWithOptionsOpaque(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := NewWithOptions(style, WithSize(100), WithOpaque(color.Black))
+		if got, want := p.MinSize().Width, NewWithOptions(style, WithSize(100), WithoutAlpha()).MinSize().Width; got != want {
+			t.Errorf("style %d: MinSize().Width = %v; want %v without the alpha bar", style, got, want)
+		}
+
+		p.SetColor(color.NRGBA{R: 0xff, A: 0x80})
+		if got, want := color.NRGBAModel.Convert(p.Color()), (color.NRGBA{R: 0x80, A: 0xff}); got != want {
+			t.Errorf("style %d: Color() = %v after SetColor; want %v", style, got, want)
+		}
+		p.SetHSVA(0, 0, 1, 0.5)
+		if got, want := color.NRGBAModel.Convert(p.Color()), (color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}); got != want {
+			t.Errorf("style %d: Color() = %v after SetHSVA; want %v", style, got, want)
+		}
+		if _, _, _, a := p.HSVA(); a != 1 {
+			t.Errorf("style %d: alpha = %v; want 1", style, a)
+		}
+	}
+}
+
+func TestNewWithOptionsOpaqueInitialColor(t *testing.T) {
+	test.NewTempApp(t)
+	p := NewWithOptions(StyleHue, WithOpaque(nil), WithInitialColor(color.NRGBA{B: 0xff, A: 0x80}))
+	if got, want := color.NRGBAModel.Convert(p.Color()), (color.NRGBA{R: 0x7f, G: 0x7f, B: 0xff, A: 0xff}); got != want {
+		t.Errorf("Color() = %v; want %v flattened against white", got, want)
+	}
+}
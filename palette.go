@@ -0,0 +1,375 @@
+package colorpicker
+
+import (
+	"encoding/json"
+	"errors"
+	"image/color"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/lusingander/colorpicker/csscolor"
+)
+
+var (
+	paletteSwatchSize = fyne.NewSize(32, 32)
+
+	errNoPaletteColor = errors.New("palette entry has no color")
+)
+
+// PaletteEntry is a named color of a Palette.
+type PaletteEntry struct {
+	Name  string
+	Color color.Color
+}
+
+type paletteEntryJSON struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// MarshalJSON encodes the entry with its color as a CSS hex color string.
+// It returns an error if the entry has no color.
+func (e PaletteEntry) MarshalJSON() ([]byte, error) {
+	if e.Color == nil {
+		return nil, errNoPaletteColor
+	}
+	return json.Marshal(paletteEntryJSON{Name: e.Name, Color: csscolor.Format(e.Color, csscolor.NotationHex)})
+}
+
+// UnmarshalJSON decodes the entry with its color as any CSS color string.
+func (e *PaletteEntry) UnmarshalJSON(data []byte) error {
+	var v paletteEntryJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	c, err := csscolor.Parse(v.Color)
+	if err != nil {
+		return err
+	}
+	e.Name, e.Color = v.Name, c
+	return nil
+}
+
+// Palette is an ordered list of named colors.
+type Palette []PaletteEntry
+
+// Add appends a color named name.
+func (p *Palette) Add(name string, c color.Color) {
+	*p = append(*p, PaletteEntry{Name: name, Color: c})
+}
+
+// Remove removes the entry at i.
+func (p *Palette) Remove(i int) {
+	*p = slices.Delete(*p, i, i+1)
+}
+
+// Rename sets the name of the entry at i.
+func (p *Palette) Rename(i int, name string) {
+	(*p)[i].Name = name
+}
+
+// Move moves the entry at from to to, shifting the entries in between.
+func (p *Palette) Move(from, to int) {
+	e := (*p)[from]
+	*p = slices.Insert(slices.Delete(*p, from, from+1), to, e)
+}
+
+// PaletteStore loads and saves the palette of a SwatchPalette, so that apps can persist it.
+type PaletteStore interface {
+	LoadPalette() (Palette, error)
+	SavePalette(Palette) error
+}
+
+// NewPreferencesPaletteStore returns a store that saves the palette as JSON in prefs under key.
+func NewPreferencesPaletteStore(prefs fyne.Preferences, key string) PaletteStore {
+	return &preferencesPaletteStore{prefs: prefs, key: key}
+}
+
+type preferencesPaletteStore struct {
+	prefs fyne.Preferences
+	key   string
+}
+
+func (s *preferencesPaletteStore) LoadPalette() (Palette, error) {
+	var p Palette
+	data := s.prefs.String(s.key)
+	if data == "" {
+		return p, nil
+	}
+	err := json.Unmarshal([]byte(data), &p)
+	return p, err
+}
+
+func (s *preferencesPaletteStore) SavePalette(p Palette) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	s.prefs.SetString(s.key, string(data))
+	return nil
+}
+
+// SwatchPalette is a grid of the named colors of a Palette, bound to a ColorPicker.
+// Tapping a swatch sets its color on the picker, and the add button adds the color of the picker.
+// Swatches can be reordered by dragging, and renamed or deleted from the menu of a secondary tap.
+type SwatchPalette struct {
+	widget.BaseWidget
+
+	picker    ColorPicker
+	palette   Palette
+	store     PaletteStore
+	items     []*paletteItem
+	grid      *fyne.Container
+	addButton *widget.Button
+	content   fyne.CanvasObject
+
+	changed func(Palette)
+}
+
+// NewSwatchPalette returns a grid of the colors of palette bound to picker.
+// The swatch of the color of picker is highlighted if picker was created by this package.
+func NewSwatchPalette(picker ColorPicker, palette Palette) *SwatchPalette {
+	s := &SwatchPalette{
+		picker:  picker,
+		changed: func(Palette) {},
+	}
+	s.grid = container.NewGridWrap(s.cellSize())
+	s.addButton = widget.NewButtonWithIcon("", theme.ContentAddIcon(), func() {
+		c := s.picker.Color()
+		s.update(func(p *Palette) {
+			p.Add(csscolor.Format(c, csscolor.NotationHex), c)
+		})
+	})
+	s.content = container.NewBorder(nil, nil, nil, container.NewVBox(s.addButton), s.grid)
+	if l, ok := picker.(changeListenable); ok {
+		l.addChangeListener(func(color.Color) {
+			s.updateSelection()
+		})
+	}
+	s.ExtendBaseWidget(s)
+	s.SetPalette(palette)
+	return s
+}
+
+// Palette returns a copy of the palette.
+func (s *SwatchPalette) Palette() Palette {
+	return slices.Clone(s.palette)
+}
+
+// SetPalette replaces the palette without calling the function set by SetOnChanged.
+func (s *SwatchPalette) SetPalette(p Palette) {
+	s.palette = slices.Clone(p)
+	s.items = s.items[:0]
+	s.grid.RemoveAll()
+	for i, e := range s.palette {
+		item := newPaletteItem(s, i, e)
+		s.items = append(s.items, item)
+		s.grid.Add(item)
+	}
+	s.updateSelection()
+	s.Refresh()
+}
+
+// SetOnChanged sets the function called with the palette when entries are added, moved, renamed or removed by the user.
+func (s *SwatchPalette) SetOnChanged(f func(Palette)) {
+	s.changed = f
+}
+
+// SetStore loads the palette from store, and saves it to store on every change by the user.
+// Errors on saving are logged.
+func (s *SwatchPalette) SetStore(store PaletteStore) error {
+	p, err := store.LoadPalette()
+	if err != nil {
+		return err
+	}
+	s.store = store
+	s.SetPalette(p)
+	return nil
+}
+
+func (s *SwatchPalette) update(f func(p *Palette)) {
+	f(&s.palette)
+	s.SetPalette(s.palette)
+	if s.store != nil {
+		if err := s.store.SavePalette(s.Palette()); err != nil {
+			fyne.LogError("failed to save palette", err)
+		}
+	}
+	s.changed(s.Palette())
+}
+
+func (s *SwatchPalette) updateSelection() {
+	c := s.picker.Color()
+	for _, item := range s.items {
+		item.swatch.setSelected(colorEquals(item.entry.Color, c))
+	}
+}
+
+func (s *SwatchPalette) cellSize() fyne.Size {
+	return fyne.NewSize(paletteSwatchSize.Width+theme.Padding()*2, paletteSwatchSize.Height+theme.Padding()+theme.CaptionTextSize()*1.5)
+}
+
+// indexAt returns the index of the cell at p in the grid, which is clamped to the palette.
+func (s *SwatchPalette) indexAt(p fyne.Position) int {
+	cell := s.cellSize()
+	cols := max(1, int((s.grid.Size().Width+theme.Padding())/(cell.Width+theme.Padding())))
+	col := min(max(0, int(p.X/(cell.Width+theme.Padding()))), cols-1)
+	row := max(0, int(p.Y/(cell.Height+theme.Padding())))
+	return min(row*cols+col, len(s.palette)-1)
+}
+
+func (s *SwatchPalette) showMenu(i int, e *fyne.PointEvent) {
+	c := fyne.CurrentApp().Driver().CanvasForObject(s)
+	if c == nil {
+		return
+	}
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem("Rename", func() { s.showRename(i, c) }),
+		fyne.NewMenuItem("Delete", func() {
+			s.update(func(p *Palette) { p.Remove(i) })
+		}),
+	)
+	widget.ShowPopUpMenuAtPosition(menu, c, e.AbsolutePosition)
+}
+
+func (s *SwatchPalette) showRename(i int, c fyne.Canvas) {
+	entry := widget.NewEntry()
+	entry.SetText(s.palette[i].Name)
+	var popUp *widget.PopUp
+	rename := func() {
+		popUp.Hide()
+		s.update(func(p *Palette) { p.Rename(i, entry.Text) })
+	}
+	entry.OnSubmitted = func(string) { rename() }
+	buttons := container.NewHBox(
+		layout.NewSpacer(),
+		widget.NewButton("Cancel", func() { popUp.Hide() }),
+		&widget.Button{Text: "Rename", Importance: widget.HighImportance, OnTapped: rename},
+	)
+	popUp = widget.NewModalPopUp(container.NewVBox(widget.NewLabel("Rename color"), entry, buttons), c)
+	popUp.Resize(fyne.NewSize(250, popUp.MinSize().Height))
+	popUp.Show()
+	c.Focus(entry)
+}
+
+func (s *SwatchPalette) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(s.content)
+}
+
+// paletteItem is a cell of SwatchPalette, which displays the swatch and the name of an entry.
+type paletteItem struct {
+	widget.BaseWidget
+
+	palette *SwatchPalette
+	index   int
+	entry   PaletteEntry
+	swatch  *swatch
+	label   *canvas.Text
+
+	// dragged is a copy of the item following the pointer in the overlay of canvas while the item is dragged,
+	// so that it is not moved back by the layout of the grid, where the item is left empty as a placeholder.
+	dragged *paletteItem
+	overlay *fyne.Container
+	canvas  fyne.Canvas
+}
+
+func newPaletteItem(s *SwatchPalette, i int, e PaletteEntry) *paletteItem {
+	item := &paletteItem{
+		palette: s,
+		index:   i,
+		entry:   e,
+		swatch:  newSwatch(e.Color),
+		label:   canvas.NewText(e.Name, theme.ForegroundColor()),
+	}
+	item.label.TextSize = theme.CaptionTextSize()
+	item.label.Alignment = fyne.TextAlignCenter
+	item.ExtendBaseWidget(item)
+	return item
+}
+
+func (i *paletteItem) Tapped(*fyne.PointEvent) {
+	i.palette.picker.SetColor(i.entry.Color)
+}
+
+func (i *paletteItem) TappedSecondary(e *fyne.PointEvent) {
+	i.palette.showMenu(i.index, e)
+}
+
+func (i *paletteItem) Dragged(e *fyne.DragEvent) {
+	if i.dragged == nil && !i.startDrag() {
+		return
+	}
+	i.dragged.Move(i.dragged.Position().Add(e.Dragged))
+}
+
+func (i *paletteItem) startDrag() bool {
+	i.canvas = fyne.CurrentApp().Driver().CanvasForObject(i)
+	if i.canvas == nil {
+		return false
+	}
+	i.dragged = newPaletteItem(i.palette, i.index, i.entry)
+	i.dragged.Resize(i.Size())
+	i.dragged.Move(fyne.CurrentApp().Driver().AbsolutePositionForObject(i))
+	i.overlay = container.NewWithoutLayout(i.dragged)
+	i.canvas.Overlays().Add(i.overlay)
+	i.overlay.Resize(i.canvas.Size())
+	i.swatch.Hide()
+	i.label.Hide()
+	return true
+}
+
+func (i *paletteItem) DragEnd() {
+	if i.dragged == nil {
+		return
+	}
+	i.canvas.Overlays().Remove(i.overlay)
+	pos := i.dragged.Position().Subtract(fyne.CurrentApp().Driver().AbsolutePositionForObject(i.palette.grid))
+	center := pos.Add(fyne.NewPos(i.Size().Width/2, i.Size().Height/2))
+	i.dragged, i.overlay, i.canvas = nil, nil, nil
+	from, to := i.index, i.palette.indexAt(center)
+	if from == to {
+		i.swatch.Show()
+		i.label.Show()
+		return
+	}
+	i.palette.update(func(p *Palette) { p.Move(from, to) })
+}
+
+func (i *paletteItem) CreateRenderer() fyne.WidgetRenderer {
+	return &paletteItemRenderer{item: i}
+}
+
+type paletteItemRenderer struct {
+	item *paletteItem
+}
+
+func (r *paletteItemRenderer) Layout(size fyne.Size) {
+	s := r.item.swatch
+	s.Resize(paletteSwatchSize)
+	s.Move(fyne.NewPos((size.Width-paletteSwatchSize.Width)/2, 0))
+	label := r.item.label
+	label.Resize(fyne.NewSize(size.Width, size.Height-paletteSwatchSize.Height-theme.Padding()))
+	label.Move(fyne.NewPos(0, paletteSwatchSize.Height+theme.Padding()))
+}
+
+func (r *paletteItemRenderer) MinSize() fyne.Size {
+	return r.item.palette.cellSize()
+}
+
+func (r *paletteItemRenderer) Refresh() {
+	r.item.label.Color = theme.ForegroundColor()
+	r.item.label.TextSize = theme.CaptionTextSize()
+	r.item.label.Refresh()
+	r.item.swatch.Refresh()
+}
+
+func (r *paletteItemRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.item.swatch, r.item.label}
+}
+
+func (r *paletteItemRenderer) Destroy() {}
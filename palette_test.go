@@ -0,0 +1,119 @@
+package colorpicker
+
+import (
+	"encoding/json"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+var (
+	paletteRed   = PaletteEntry{Name: "red", Color: color.NRGBA{R: 0xff, A: 0xff}}
+	paletteGreen = PaletteEntry{Name: "green", Color: color.NRGBA{G: 0xff, A: 0xff}}
+	paletteBlue  = PaletteEntry{Name: "blue", Color: color.NRGBA{B: 0xff, A: 0x80}}
+)
+
+func paletteNames(p Palette) []string {
+	names := make([]string, len(p))
+	for i, e := range p {
+		names[i] = e.Name
+	}
+	return names
+}
+
+func TestPalette(t *testing.T) {
+	var p Palette
+	p.Add(paletteRed.Name, paletteRed.Color)
+	p.Add(paletteGreen.Name, paletteGreen.Color)
+	p.Add(paletteBlue.Name, paletteBlue.Color)
+	p.Move(0, 2)
+	p.Rename(0, "lime")
+	p.Remove(1)
+	if got := paletteNames(p); len(got) != 2 || got[0] != "lime" || got[1] != "red" {
+		t.Errorf("names = %v; want [lime red]", got)
+	}
+
+	data, err := json.Marshal(Palette{paletteRed, paletteBlue})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `[{"name":"red","color":"#ff0000"},{"name":"blue","color":"#0000ff80"}]`; got != want {
+		t.Errorf("json = %s; want %s", got, want)
+	}
+	if _, err := json.Marshal(Palette{{Name: "none"}}); err == nil {
+		t.Error("json.Marshal() of an entry without color succeeded; want error")
+	}
+	var decoded Palette
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 || decoded[1] != paletteBlue {
+		t.Errorf("decoded = %v; want %v", decoded, Palette{paletteRed, paletteBlue})
+	}
+}
+
+func TestSwatchPalette(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue)
+	s := NewSwatchPalette(p, Palette{paletteRed, paletteGreen, paletteBlue})
+	w := test.NewWindow(s)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 200))
+	var changed Palette
+	s.SetOnChanged(func(p Palette) { changed = p })
+
+	test.Tap(s.items[1])
+	if got := p.Color(); !colorEquals(got, paletteGreen.Color) {
+		t.Errorf("Color() = %v after tapping green; want %v", got, paletteGreen.Color)
+	}
+	if !s.items[1].swatch.selected || s.items[0].swatch.selected {
+		t.Error("the swatch of the color of the picker is not selected")
+	}
+
+	// drag red beyond blue, which is not moved back by the layout while dragging
+	red := s.items[0]
+	red.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(s.cellSize().Width, 0)})
+	s.grid.Refresh()
+	red.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(s.cellSize().Width, 0)})
+	if w.Canvas().Overlays().Top() == nil || red.swatch.Visible() {
+		t.Error("the dragged swatch is not displayed in an overlay")
+	}
+	red.DragEnd()
+	if w.Canvas().Overlays().Top() != nil {
+		t.Error("the overlay is not removed after the drag")
+	}
+	if got := paletteNames(changed); len(got) != 3 || got[0] != "green" || got[2] != "red" {
+		t.Errorf("names after drag = %v; want [green blue red]", got)
+	}
+
+	p.SetColor(paletteBlue.Color)
+	test.Tap(s.addButton)
+	if got := s.Palette(); len(got) != 4 || got[3].Name != "#0000ff80" {
+		t.Errorf("palette after add = %v; want blue appended", got)
+	}
+}
+
+func TestSwatchPaletteStore(t *testing.T) {
+	a := test.NewTempApp(t)
+	a.Preferences().SetString("palette", `[{"name":"red","color":"red"}]`)
+	store := NewPreferencesPaletteStore(a.Preferences(), "palette")
+	s := NewSwatchPalette(New(200, StyleHue), nil)
+	if err := s.SetStore(store); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Palette(); len(got) != 1 || got[0] != paletteRed {
+		t.Fatalf("Palette() = %v; want %v", got, Palette{paletteRed})
+	}
+
+	s.update(func(p *Palette) { p.Rename(0, "primary") })
+	if got, want := a.Preferences().String("palette"), `[{"name":"primary","color":"#ff0000"}]`; got != want {
+		t.Errorf("preferences = %s; want %s", got, want)
+	}
+
+	a.Preferences().SetString("palette", "{")
+	if err := s.SetStore(store); err == nil {
+		t.Error("SetStore() with invalid JSON succeeded; want error")
+	}
+}
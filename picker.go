@@ -1,6 +1,7 @@
 package colorpicker
 
 import (
+	"image"
 	"image/color"
 	"math"
 
@@ -9,6 +10,12 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
+
+	"github.com/lusingander/colorpicker/colorspace"
+)
+
+const (
+	defaultScrollStep = 0.01
 )
 
 var (
@@ -19,12 +26,275 @@ type colorPickerBase struct {
 	fyne.CanvasObject
 	colorPickerRaster *tappableRaster
 	changed           func(color.Color)
+	listeners         []func(color.Color)
+	// commitListeners are called with the colors before and after each committed change.
+	commitListeners  []func(from, to color.Color)
+	shortcutHandlers []func(fyne.Shortcut) bool
+	keyHandlers      []func(*fyne.KeyEvent)
+	disableListeners []func()
+	// unbind unbinds the picker from the data bound by NewWithData, if any.
+	unbind func()
+
+	changeStarted, changeEnded, committed func(color.Color)
+	// current returns the selected color, and startColor is the color when the current change started.
+	current    func() color.Color
+	startColor color.Color
+	// rasters are the rasters of the area and the bars, which ignore the user while the picker is disabled.
+	rasters  []*tappableRaster
+	disabled bool
+
+	hue, saturation, value, alpha float64
+	// areaInput is the hue, saturation or value the pixel colors of colorPickerRaster were created from.
+	areaInput     float64
+	barScrollStep float64
+	// barWidthRatio is the width of the bars relative to the height of the picker.
+	barWidthRatio float32
+	// background is the color translucent colors are flattened against, or nil unless the picker is opaque.
+	background color.Color
+	horizontal bool
+	updateView func()
+}
+
+func newColorPickerBase(o *options) *colorPickerBase {
+	return &colorPickerBase{
+		changed:       func(color.Color) {},
+		changeStarted: func(color.Color) {},
+		changeEnded:   func(color.Color) {},
+		committed:     func(color.Color) {},
+		areaInput:     math.NaN(),
+		hue:           0,
+		saturation:    0,
+		value:         1,
+		alpha:         1,
+
+		barScrollStep: defaultScrollStep,
+		barWidthRatio: o.barWidthRatio,
+		background:    o.opaqueBackground,
+		horizontal:    o.orientation == OrientationHorizontal,
+	}
+}
+
+// setAreaInput replaces the pixel colors of colorPickerRaster with create(in),
+// unless they were already created from in, so that the area is only regenerated when it looks different.
+func (p *colorPickerBase) setAreaInput(in float64, create func(float64) pixelColorFunc) {
+	if in == p.areaInput {
+		return
+	}
+	p.areaInput = in
+	p.colorPickerRaster.setPixelColor(create(in))
+	p.colorPickerRaster.Refresh()
+}
+
+// barSize returns the size of the bars next to the area of size x size.
+func (p *colorPickerBase) barSize(size float32) fyne.Size {
+	if p.horizontal {
+		return fyne.NewSize(size, size*p.barWidthRatio)
+	}
+	return fyne.NewSize(size*p.barWidthRatio, size)
 }
 
 func (p *colorPickerBase) SetOnChanged(f func(color.Color)) {
 	p.changed = f
 }
 
+func (p *colorPickerBase) SetOnChangeStarted(f func(color.Color)) {
+	p.changeStarted = f
+}
+
+func (p *colorPickerBase) SetOnChangeEnded(f func(color.Color)) {
+	p.changeEnded = f
+}
+
+func (p *colorPickerBase) SetOnCommitted(f func(color.Color)) {
+	p.committed = f
+}
+
+// watchChanges makes each tap, drag, key press or scroll on the rasters a single change of the color returned by current.
+func (p *colorPickerBase) watchChanges(current func() color.Color, rasters ...*tappableRaster) {
+	p.current = current
+	p.rasters = rasters
+	for _, r := range rasters {
+		r.started = p.startChange
+		r.ended = p.endChange
+		r.shortcut = p.typedShortcut
+		r.keyTyped = p.typedKey
+	}
+}
+
+func (p *colorPickerBase) startChange() {
+	// a scroll gesture in progress ends before another change starts
+	for _, r := range p.rasters {
+		r.endScroll()
+	}
+	p.startColor = p.current()
+	p.changeStarted(p.startColor)
+}
+
+func (p *colorPickerBase) endChange() {
+	c := p.current()
+	p.changeEnded(c)
+	if c != p.startColor {
+		p.committed(c)
+		for _, f := range p.commitListeners {
+			f(p.startColor, c)
+		}
+	}
+}
+
+// addCommitListener registers f to be called after the OnCommitted callback with the colors before and after the change.
+func (p *colorPickerBase) addCommitListener(f func(from, to color.Color)) {
+	p.commitListeners = append(p.commitListeners, f)
+}
+
+// addShortcutHandler registers f to be called with the shortcuts typed while the picker has focus,
+// which reports whether it has handled the shortcut.
+func (p *colorPickerBase) addShortcutHandler(f func(fyne.Shortcut) bool) {
+	p.shortcutHandlers = append(p.shortcutHandlers, f)
+}
+
+// typedShortcut reports whether any of the handlers has handled s.
+func (p *colorPickerBase) typedShortcut(s fyne.Shortcut) bool {
+	handled := false
+	for _, f := range p.shortcutHandlers {
+		if f(s) {
+			handled = true
+		}
+	}
+	return handled
+}
+
+// addKeyHandler registers f to be called with the keys typed while the picker has focus, which do not move the markers.
+func (p *colorPickerBase) addKeyHandler(f func(*fyne.KeyEvent)) {
+	p.keyHandlers = append(p.keyHandlers, f)
+}
+
+func (p *colorPickerBase) typedKey(e *fyne.KeyEvent) {
+	for _, f := range p.keyHandlers {
+		f(e)
+	}
+}
+
+// addChangeListener registers f to be called after the OnChanged callback,
+// so that attached widgets can follow the color without replacing the callback.
+func (p *colorPickerBase) addChangeListener(f func(color.Color)) {
+	p.listeners = append(p.listeners, f)
+}
+
+func (p *colorPickerBase) fireChanged(c color.Color) {
+	p.changed(c)
+	for _, f := range p.listeners {
+		f(c)
+	}
+}
+
+// Disable makes the picker ignore taps, drags, keys and scrolling, and dims its colors.
+// The color can still be set by SetColor and SetHSVA.
+func (p *colorPickerBase) Disable() {
+	p.disabled = true
+	for _, r := range p.rasters {
+		r.Disable()
+	}
+	p.fireDisabledChanged()
+}
+
+// Enable makes the picker respond to the user again.
+func (p *colorPickerBase) Enable() {
+	p.disabled = false
+	for _, r := range p.rasters {
+		r.Enable()
+	}
+	p.fireDisabledChanged()
+}
+
+// addDisableListener registers f to be called when the picker is disabled or enabled,
+// so that attached widgets can follow the state.
+func (p *colorPickerBase) addDisableListener(f func()) {
+	p.disableListeners = append(p.disableListeners, f)
+}
+
+func (p *colorPickerBase) fireDisabledChanged() {
+	for _, f := range p.disableListeners {
+		f()
+	}
+}
+
+// Unbind stops following the data bound by NewWithData and setting the color to it.
+func (p *colorPickerBase) Unbind() {
+	if p.unbind == nil {
+		return
+	}
+	p.unbind()
+	p.unbind = nil
+}
+
+func (p *colorPickerBase) setUnbind(f func()) {
+	p.unbind = f
+}
+
+// Disabled reports whether the picker is disabled.
+func (p *colorPickerBase) Disabled() bool {
+	return p.disabled
+}
+
+func (p *colorPickerBase) SetScrollStep(step float64) {
+	p.barScrollStep = step
+}
+
+func (p *colorPickerBase) scrollStep() float64 {
+	return p.barScrollStep
+}
+
+// flatten returns c flattened against the background if the picker is opaque, or c as is otherwise.
+func (p *colorPickerBase) flatten(c color.Color) color.Color {
+	if !p.opaque() {
+		return c
+	}
+	return flattenColor(c, p.background)
+}
+
+// opaque reports whether the picker keeps the color fully opaque.
+func (p *colorPickerBase) opaque() bool {
+	return p.background != nil
+}
+
+// isFlattened reports whether the alpha a must be flattened, in which case the color should be set by SetColor.
+func (p *colorPickerBase) isFlattened(a float64) bool {
+	return p.opaque() && a < 1
+}
+
+func (p *colorPickerBase) Color() color.Color {
+	return fromHSVA(p.hue, p.saturation, p.value, p.alpha)
+}
+
+func (p *colorPickerBase) HSVA() (h, s, v, a float64) {
+	return p.hue, p.saturation, p.value, p.alpha
+}
+
+func (p *colorPickerBase) SetHSVA(h, s, v, a float64) {
+	if p.isFlattened(a) {
+		p.SetColor(fromHSVA(clamp01(h), clamp01(s), clamp01(v), clamp01(a)))
+		return
+	}
+	p.hue = clamp01(h)
+	p.saturation = clamp01(s)
+	p.value = clamp01(v)
+	p.alpha = clamp01(a)
+	p.updateView()
+	p.fireChanged(p.Color())
+}
+
+func (p *colorPickerBase) SetColor(c color.Color) {
+	h, s, v, a := fromColor(p.flatten(c))
+	// hue (and saturation if black) is undefined for achromatic colors, so keep the current one
+	if s == 0 || v == 0 {
+		h = p.hue
+	}
+	if v == 0 {
+		s = p.saturation
+	}
+	p.SetHSVA(h, s, v, a)
+}
+
 func (p *colorPickerBase) CreateRenderer() fyne.WidgetRenderer {
 	return &colorPickerBaseWidgetRender{picker: p}
 }
@@ -70,289 +340,334 @@ type defaultHueColorPicker struct {
 
 	pickerWidth  float32
 	pickerHeight float32
-	barWidth     float32
-	hue          float32
 	colorMarker  marker
-	hueMarker    barMarker
+	hueBar       *pickerBar
 	*alphaPickerBar
 }
 
-func newDefaultHueColorPicker(size float32) ColorPicker {
-	pickerSize := fyne.NewSize(size, size)
-	barSize := fyne.NewSize(size/10, size)
-
+func newDefaultHueColorPicker(o *options) ColorPicker {
 	picker := &defaultHueColorPicker{
-		hue:          0,
-		pickerWidth:  pickerSize.Width,
-		pickerHeight: pickerSize.Height,
-		barWidth:     barSize.Width,
-		colorPickerBase: &colorPickerBase{
-			changed: func(color.Color) {},
-		},
+		colorPickerBase: newColorPickerBase(o),
 	}
+	picker.updateView = picker.updateAll
 
 	colorPickerRaster := newTappableRaster(createSaturationValueColorPickerPixelColor(picker.hue))
-	colorPickerRaster.SetMinSize(pickerSize)
 	colorPickerRaster.tapped = func(p fyne.Position) {
-		picker.colorMarker.setPosition(p)
+		picker.saturation = clamp01(float64(p.X / picker.pickerWidth))
+		picker.value = 1.0 - clamp01(float64(p.Y/picker.pickerHeight))
+		picker.updateColorMarker()
+		picker.updatePickerColor()
+	}
+	colorPickerRaster.moved = func(dx, dy float64) {
+		picker.saturation = clamp01(picker.saturation + dx)
+		picker.value = clamp01(picker.value - dy)
+		picker.updateColorMarker()
 		picker.updatePickerColor()
-		colorPickerRaster.Refresh()
 	}
-	colorPickerRaster.Resize(pickerSize) // Note: doesn't render if remove this line...
 	picker.colorPickerRaster = colorPickerRaster
 
-	huePickerRaster := newTappableRaster(hueBarPicker)
-	huePickerRaster.SetMinSize(barSize)
-	huePickerRaster.tapped = func(p fyne.Position) {
-		picker.hue = p.Y / barSize.Height
-		colorPickerRaster.setPixelColor(createSaturationValueColorPickerPixelColor(picker.hue))
-		colorPickerRaster.Refresh()
-		setPositionY(picker.hueMarker, p.Y)
+	picker.hueBar = newPickerBar(o, false, hueBarColor, picker.scrollStep, func(h float64) {
+		picker.hue = h
+		picker.updateHue()
 		picker.updatePickerColor()
-	}
-	huePickerRaster.Resize(barSize)
+	})
 
-	picker.alphaPickerBar = newAlphaPickerBar(barSize, picker.updatePickerColor)
+	picker.alphaPickerBar = newAlphaPickerBar(func(a float64) {
+		picker.alpha = a
+		picker.updatePickerColor()
+	}, picker.scrollStep, o)
 
-	picker.colorMarker = newDefaultMarker(5)
-	picker.hueMarker = newDefaultBarMarker(picker.barWidth)
-	picker.hueMarker.setPosition(fyne.NewPos(picker.hueBarCenter(), 0))
+	picker.colorMarker = newStyledMarker(o.markerStyle)
 
-	picker.CanvasObject = newSpaceCenteredLayout(
-		fyne.NewContainer(colorPickerRaster, picker.colorMarker.object()),
-		fyne.NewContainer(huePickerRaster, picker.hueMarker.object()),
-		picker.alphaPickerBar.object(),
+	picker.watchChanges(picker.Color, colorPickerRaster, picker.hueBar.raster.tappableRaster, picker.alphaPickerBar.raster.tappableRaster)
+	picker.CanvasObject = o.newPickerLayout(
+		picker.resized,
+		picker.alphaPickerBar,
+		container.NewWithoutLayout(colorPickerRaster, picker.colorMarker.object()),
+		picker.hueBar.object(),
 	)
+	picker.updateAll()
 	return picker
 }
 
-func (p *defaultHueColorPicker) updatePickerColor() {
-	x := p.colorMarker.position().X
-	y := p.colorMarker.position().Y
-	color := fromHSVA(float64(p.hue), float64(x)/float64(p.pickerWidth), 1.0-float64(y)/float64(p.pickerHeight), float64(p.alpha))
-	p.changed(color)
+func (p *defaultHueColorPicker) resized(size float32) {
+	p.pickerWidth = size
+	p.pickerHeight = size
+	p.colorPickerRaster.Resize(fyne.NewSize(size, size))
+	p.hueBar.resize(p.barSize(size))
+	p.alphaPickerBar.resize(p.barSize(size))
+	p.updateAll()
+}
 
-	p.alphaPickerBar.setColor(color)
+func (p *defaultHueColorPicker) updateAll() {
+	p.updateHue()
+	p.updateColorMarker()
+	p.setAlpha(p.alpha)
+	p.alphaPickerBar.setColor(p.Color())
 }
 
-func (p *defaultHueColorPicker) SetColor(c color.Color) {
-	h, s, v, a := fromColor(c)
-	p.hue = float32(h)
-	setPositionY(p.hueMarker, p.pickerHeight*float32(h))
-	p.colorPickerRaster.setPixelColor(createSaturationValueColorPickerPixelColor(p.hue))
-	p.colorPickerRaster.Refresh()
-	x := float32(math.Round(float64(p.pickerWidth) * s))
-	y := float32(math.Round(float64(p.pickerHeight) * (1.0 - v)))
+func (p *defaultHueColorPicker) updateHue() {
+	p.hueBar.setValue(p.hue)
+	p.setAreaInput(p.hue, createSaturationValueColorPickerPixelColor)
+}
+
+func (p *defaultHueColorPicker) updateColorMarker() {
+	x := float32(math.Round(float64(p.pickerWidth) * p.saturation))
+	y := float32(math.Round(float64(p.pickerHeight) * (1.0 - p.value)))
 	p.colorMarker.setPosition(fyne.NewPos(x, y))
-	p.setAlpha(float32(a))
-	p.updatePickerColor()
+	p.colorMarker.Refresh()
 }
 
-func (p *defaultHueColorPicker) hueBarCenter() float32 {
-	return float32(p.barWidth) / 2
+func (p *defaultHueColorPicker) updatePickerColor() {
+	color := p.Color()
+	p.fireChanged(color)
+
+	p.alphaPickerBar.setColor(color)
 }
 
 type circleHueColorPicker struct {
 	*colorPickerBase
 
-	pickerWidth    float32
-	pickerHeight   float32
-	hueCircleWidth float32
-	hue            float32
-	colorMarker    marker
-	hueMarker      barMarker
+	pickerWidth           float32
+	pickerHeight          float32
+	hueCircleWidth        float32
+	colorMarker           marker
+	hueMarker             barMarker
+	colorPickerArea       *fyne.Container
+	circleHuePickerRaster *scrollableRaster
 	*alphaPickerBar
 }
 
-func newCircleHueColorPicker(size float32) ColorPicker {
-	// pickerAreaWidth < ((areaWidth - (hueBarWidth * 2)) / √2)
-	pickerAreaWidth := (size - (size/10)*2) / 1.45
-	pickerSize := fyne.NewSize(pickerAreaWidth, pickerAreaWidth)
-	hueSize := fyne.NewSize(size, size)
-	barSize := fyne.NewSize(size/10, size)
-
+func newCircleHueColorPicker(o *options) ColorPicker {
 	picker := &circleHueColorPicker{
-		hue:            0,
-		pickerWidth:    pickerSize.Width,
-		pickerHeight:   pickerSize.Height,
-		hueCircleWidth: hueSize.Width,
-		colorPickerBase: &colorPickerBase{
-			changed: func(color.Color) {},
-		},
+		colorPickerBase: newColorPickerBase(o),
 	}
+	picker.updateView = picker.updateAll
 
 	colorPickerRaster := newTappableRaster(createSaturationValueColorPickerPixelColor(picker.hue))
-	colorPickerRaster.SetMinSize(pickerSize)
 	colorPickerRaster.tapped = func(p fyne.Position) {
-		picker.colorMarker.setPosition(p)
+		picker.saturation = clamp01(float64(p.X / picker.pickerWidth))
+		picker.value = 1.0 - clamp01(float64(p.Y/picker.pickerHeight))
+		picker.updateColorMarker()
+		picker.updatePickerColor()
+	}
+	colorPickerRaster.moved = func(dx, dy float64) {
+		picker.saturation = clamp01(picker.saturation + dx)
+		picker.value = clamp01(picker.value - dy)
+		picker.updateColorMarker()
 		picker.updatePickerColor()
-		colorPickerRaster.Refresh()
 	}
-	colorPickerRaster.Resize(pickerSize) // Note: doesn't render if remove this line...
 	picker.colorPickerRaster = colorPickerRaster
 
-	circleHuePickerRaster := newTappableRaster(circleHuePicker)
-	circleHuePickerRaster.SetMinSize(hueSize)
+	circleHuePickerRaster := newScrollableRaster(createCircleHuePickerPixelColor(o.barWidthRatio), picker.scrollStep)
 	circleHuePickerRaster.tapped = func(p fyne.Position) {
-		picker.hue = picker.hueMarker.calcValueFromPosition(p)
-		colorPickerRaster.setPixelColor(createSaturationValueColorPickerPixelColor(picker.hue))
-		colorPickerRaster.Refresh()
-		picker.hueMarker.setPosition(p)
+		picker.hue = float64(picker.hueMarker.calcValueFromPosition(p))
+		picker.updateHue()
+		picker.updatePickerColor()
+	}
+	circleHuePickerRaster.moved = func(dx, dy float64) {
+		if dy == 0 {
+			dy = dx
+		}
+		if math.Abs(dy) >= 1 {
+			// Home and End move to the ends of the hue
+			picker.hue = clamp01(picker.hue + dy)
+		} else {
+			// the hue wraps around the ring
+			picker.hue = math.Mod(picker.hue+dy+1, 1)
+		}
+		picker.updateHue()
 		picker.updatePickerColor()
 	}
-	circleHuePickerRaster.Resize(hueSize)
+	picker.circleHuePickerRaster = circleHuePickerRaster
 
-	picker.alphaPickerBar = newAlphaPickerBar(barSize, picker.updatePickerColor)
+	picker.alphaPickerBar = newAlphaPickerBar(func(a float64) {
+		picker.alpha = a
+		picker.updatePickerColor()
+	}, picker.scrollStep, o)
 
-	picker.colorMarker = newDefaultMarker(5)
-	picker.hueMarker = newCircleBarMarker(hueSize.Width, hueSize.Height, picker.cirlceHueBarWidth())
+	picker.colorMarker = newStyledMarker(o.markerStyle)
+	picker.hueMarker = newCircleBarMarker(o.markerStyle)
 
-	picker.CanvasObject = newSpaceCenteredLayout(
-		container.New(
-			layout.NewCenterLayout(),
+	picker.colorPickerArea = container.NewWithoutLayout(
+		colorPickerRaster,
+		picker.colorMarker.object(),
+	)
+	picker.watchChanges(picker.Color, colorPickerRaster, picker.circleHuePickerRaster.tappableRaster, picker.alphaPickerBar.raster.tappableRaster)
+	picker.CanvasObject = o.newPickerLayout(
+		picker.resized,
+		picker.alphaPickerBar,
+		container.NewWithoutLayout(
 			container.NewWithoutLayout(
 				circleHuePickerRaster,
 				picker.hueMarker.object(),
 			),
-			container.NewWithoutLayout(
-				colorPickerRaster,
-				picker.colorMarker.object(),
-			),
+			picker.colorPickerArea,
 		),
-		picker.alphaPickerBar.object(),
 	)
+	picker.updateAll()
 	return picker
 }
 
-func (p *circleHueColorPicker) cirlceHueBarWidth() float32 {
-	return float32(p.hueCircleWidth) / 10
+func (p *circleHueColorPicker) resized(size float32) {
+	hueCircleBarWidth := size * p.barWidthRatio
+	// pickerAreaWidth < ((areaWidth - (hueBarWidth * 2)) / √2)
+	pickerAreaWidth := float32(math.Floor(float64((size - hueCircleBarWidth*2) / 1.45)))
+	p.pickerWidth = pickerAreaWidth
+	p.pickerHeight = pickerAreaWidth
+	p.hueCircleWidth = size
+	hueSize := fyne.NewSize(size, size)
+	pickerSize := fyne.NewSize(pickerAreaWidth, pickerAreaWidth)
+	p.circleHuePickerRaster.Resize(hueSize)
+	p.hueMarker.setBarSize(hueSize, hueCircleBarWidth)
+	offset := float32(math.Round(float64(size-pickerAreaWidth) / 2))
+	p.colorPickerArea.Move(fyne.NewPos(offset, offset))
+	p.colorPickerArea.Resize(pickerSize)
+	p.colorPickerRaster.Resize(pickerSize)
+	p.alphaPickerBar.resize(p.barSize(size))
+	p.updateAll()
 }
 
-func (p *circleHueColorPicker) updatePickerColor() {
-	x := p.colorMarker.position().X
-	y := p.colorMarker.position().Y
-	color := fromHSVA(float64(p.hue), float64(x)/float64(p.pickerWidth), 1.0-float64(y)/float64(p.pickerHeight), float64(p.alpha))
-	p.changed(color)
+func (p *circleHueColorPicker) updateAll() {
+	p.updateHue()
+	p.updateColorMarker()
+	p.setAlpha(p.alpha)
+	p.alphaPickerBar.setColor(p.Color())
+}
 
-	p.alphaPickerBar.setColor(color)
+func (p *circleHueColorPicker) updateHue() {
+	p.hueMarker.setPositionFromValue(float32(p.hue))
+	p.hueMarker.Refresh()
+	p.setAreaInput(p.hue, createSaturationValueColorPickerPixelColor)
 }
 
-func (p *circleHueColorPicker) SetColor(c color.Color) {
-	h, s, v, a := fromColor(c)
-	p.hue = float32(h)
-	p.hueMarker.setPositionFromValue(p.hue)
-	p.colorPickerRaster.setPixelColor(createSaturationValueColorPickerPixelColor(p.hue))
-	p.colorPickerRaster.Refresh()
-	x := float32(math.Round(float64(p.pickerWidth) * s))
-	y := float32(math.Round(float64(p.pickerHeight) * (1.0 - v)))
+func (p *circleHueColorPicker) updateColorMarker() {
+	x := float32(math.Round(float64(p.pickerWidth) * p.saturation))
+	y := float32(math.Round(float64(p.pickerHeight) * (1.0 - p.value)))
 	p.colorMarker.setPosition(fyne.NewPos(x, y))
-	p.setAlpha(float32(a))
-	p.updatePickerColor()
+	p.colorMarker.Refresh()
+}
+
+func (p *circleHueColorPicker) updatePickerColor() {
+	color := p.Color()
+	p.fireChanged(color)
+
+	p.alphaPickerBar.setColor(color)
 }
 
 type valueColorPicker struct {
 	*colorPickerBase
 
-	pickerRadius      float32
-	pickerCenter      fyne.Position
-	valueBarWidth     float32
-	value             float32
-	colorMarker       marker
-	valueMarker       barMarker
-	valuePickerRaster *tappableRaster
+	pickerRadius float32
+	pickerCenter fyne.Position
+	colorMarker  marker
+	valueBar     *pickerBar
 	*alphaPickerBar
 }
 
-func newValueColorPicker(size float32) ColorPicker {
-	pickerSize := fyne.NewSize(size, size)
-	barSize := fyne.NewSize(size/10, size)
-
+func newValueColorPicker(o *options) ColorPicker {
 	picker := &valueColorPicker{
-		value:         1.,
-		pickerRadius:  size / 2,
-		pickerCenter:  fyne.NewPos(size/2, size/2),
-		valueBarWidth: size / 10,
-		colorPickerBase: &colorPickerBase{
-			changed: func(color.Color) {},
-		},
+		colorPickerBase: newColorPickerBase(o),
 	}
+	picker.updateView = picker.updateAll
 
 	colorPickerRaster := newTappableRaster(createCircleHueSaturationColorPickerPixelColor(picker.value))
-	colorPickerRaster.SetMinSize(pickerSize)
 	colorPickerRaster.tapped = func(p fyne.Position) {
 		if picker.isInPickerArea(p) {
-			picker.colorMarker.setPosition(p)
+			picker.hue, picker.saturation = calcHueSaturationFromCirclePoint(
+				float64(p.X),
+				float64(p.Y),
+				float64(picker.pickerCenter.X),
+				float64(picker.pickerCenter.Y),
+			)
+			picker.updateHueSaturation()
 			picker.updatePickerColor()
-			colorPickerRaster.Refresh()
 		}
 	}
-	colorPickerRaster.Resize(pickerSize) // Note: doesn't render if remove this line...
+	colorPickerRaster.moved = func(dx, dy float64) {
+		picker.moveHueSaturation(dx, dy)
+		picker.updateHueSaturation()
+		picker.updatePickerColor()
+	}
 	picker.colorPickerRaster = colorPickerRaster
 
-	valuePickerRaster := newTappableRaster(createValueBarPicker(0., 0.))
-	valuePickerRaster.SetMinSize(barSize)
-	valuePickerRaster.tapped = func(p fyne.Position) {
-		picker.value = 1.0 - p.Y/barSize.Height
-		colorPickerRaster.setPixelColor(createCircleHueSaturationColorPickerPixelColor(picker.value))
-		colorPickerRaster.Refresh()
-		setPositionY(picker.valueMarker, p.Y)
+	picker.valueBar = newPickerBar(o, true, createValueBarColor(0., 0.), picker.scrollStep, func(v float64) {
+		picker.value = v
+		picker.updateValue()
 		picker.updatePickerColor()
-	}
-	valuePickerRaster.Resize(barSize)
-	picker.valuePickerRaster = valuePickerRaster
+	})
 
-	picker.alphaPickerBar = newAlphaPickerBar(barSize, picker.updatePickerColor)
+	picker.alphaPickerBar = newAlphaPickerBar(func(a float64) {
+		picker.alpha = a
+		picker.updatePickerColor()
+	}, picker.scrollStep, o)
 
-	picker.colorMarker = newDefaultMarker(5)
-	picker.colorMarker.setPosition(picker.pickerCenter)
-	picker.valueMarker = newDefaultBarMarker(picker.valueBarWidth)
-	picker.valueMarker.setPosition(fyne.NewPos(picker.valueBarCenter(), 0))
+	picker.colorMarker = newStyledMarker(o.markerStyle)
 
-	picker.CanvasObject = newSpaceCenteredLayout(
+	picker.watchChanges(picker.Color, colorPickerRaster, picker.valueBar.raster.tappableRaster, picker.alphaPickerBar.raster.tappableRaster)
+	picker.CanvasObject = o.newPickerLayout(
+		picker.resized,
+		picker.alphaPickerBar,
 		container.NewWithoutLayout(colorPickerRaster, picker.colorMarker.object()),
-		container.NewWithoutLayout(valuePickerRaster, picker.valueMarker.object()),
-		picker.alphaPickerBar.object(),
+		picker.valueBar.object(),
 	)
+	picker.updateAll()
 	return picker
 }
 
-func (p *valueColorPicker) SetColor(c color.Color) {
-	h, s, v, a := fromColor(c)
-	p.value = float32(v)
-	areaSize := p.pickerRadius * 2
-	setPositionY(p.valueMarker, areaSize*(1.0-p.value))
-	p.colorPickerRaster.setPixelColor(createCircleHueSaturationColorPickerPixelColor(p.value))
-	p.colorPickerRaster.Refresh()
+func (p *valueColorPicker) resized(size float32) {
+	p.pickerRadius = size / 2
+	p.pickerCenter = fyne.NewPos(size/2, size/2)
+	p.colorPickerRaster.Resize(fyne.NewSize(size, size))
+	p.valueBar.resize(p.barSize(size))
+	p.alphaPickerBar.resize(p.barSize(size))
+	p.updateAll()
+}
+
+func (p *valueColorPicker) updateAll() {
+	p.updateValue()
+	p.updateHueSaturation()
+	p.setAlpha(p.alpha)
+	p.alphaPickerBar.setColor(p.Color())
+}
 
+func (p *valueColorPicker) updateValue() {
+	p.valueBar.setValue(p.value)
+	p.setAreaInput(p.value, createCircleHueSaturationColorPickerPixelColor)
+}
+
+func (p *valueColorPicker) updateHueSaturation() {
 	baseV := newVector(1, 0)
-	rad := -2 * math.Pi * h
-	vec := baseV.rotate(rad).multiply(float64(p.pickerRadius) * s)
+	rad := -2 * math.Pi * p.hue
+	vec := baseV.rotate(rad).multiply(float64(p.pickerRadius) * p.saturation)
 	center := newVector(float64(p.pickerCenter.X), float64(p.pickerCenter.Y))
 	p.colorMarker.setPosition(center.add(vec).toPosition())
-	p.setAlpha(float32(a))
-	p.updatePickerColor()
+	p.colorMarker.Refresh()
+
+	p.valueBar.setBarColor(createValueBarColor(p.hue, p.saturation))
 }
 
 func (p *valueColorPicker) updatePickerColor() {
-	c := calcColorFromCirclePointAndValue(
-		float64(p.colorMarker.position().X),
-		float64(p.colorMarker.position().Y),
-		float64(p.pickerCenter.X),
-		float64(p.pickerCenter.Y),
-		float64(p.value),
-	)
-	rgba, _ := c.(color.NRGBA)
-	rgba.A = roundUint8(float64(p.alpha) * 255)
-	p.changed(rgba)
+	color := p.Color()
+	p.fireChanged(color)
 
-	// TODO: should not recalculate...
-	h, s, v, _ := fromColor(c)
-	if v > 0 {
-		p.valuePickerRaster.setPixelColor(createValueBarPicker(float32(h), float32(s)))
-		p.valuePickerRaster.Refresh()
-	}
+	p.alphaPickerBar.setColor(color)
+}
 
-	p.alphaPickerBar.setColor(rgba)
+// moveHueSaturation moves the selected point on the hue-saturation circle, keeping it inside the circle.
+func (p *valueColorPicker) moveHueSaturation(dx, dy float64) {
+	r := float64(p.pickerRadius)
+	rad := -2 * math.Pi * p.hue
+	x := math.Cos(rad)*r*p.saturation + dx*r*2
+	y := math.Sin(rad)*r*p.saturation + dy*r*2
+	if d := math.Sqrt(square(x) + square(y)); d > r {
+		x, y = x*r/d, y*r/d
+	}
+	if x == 0 && y == 0 {
+		p.saturation = 0
+		return
+	}
+	p.hue, p.saturation = calcHueSaturationFromCirclePoint(x+r, y+r, r, r)
 }
 
 func (p *valueColorPicker) isInPickerArea(pos fyne.Position) bool {
@@ -360,152 +675,418 @@ func (p *valueColorPicker) isInPickerArea(pos fyne.Position) bool {
 	return d <= float64(p.pickerRadius)
 }
 
-func (p *valueColorPicker) valueBarCenter() float32 {
-	return float32(p.valueBarWidth) / 2
-}
-
 type saturationColorPicker struct {
 	*colorPickerBase
 
-	pickerWidth            float32
-	pickerHeight           float32
-	saturationBarWidth     float32
-	saturation             float32
-	colorMarker            marker
-	saturationMarker       barMarker
-	saturationPickerRaster *tappableRaster
+	pickerWidth   float32
+	pickerHeight  float32
+	colorMarker   marker
+	saturationBar *pickerBar
 	*alphaPickerBar
 }
 
-func newSaturationColorPicker(size float32) ColorPicker {
-	pickerSize := fyne.NewSize(size, size)
-	barSize := fyne.NewSize(size/10, size)
-
+func newSaturationColorPicker(o *options) ColorPicker {
 	picker := &saturationColorPicker{
-		saturation:         0,
-		pickerWidth:        pickerSize.Width,
-		pickerHeight:       pickerSize.Height,
-		saturationBarWidth: barSize.Width,
-		colorPickerBase: &colorPickerBase{
-			changed: func(color.Color) {},
-		},
+		colorPickerBase: newColorPickerBase(o),
 	}
+	picker.updateView = picker.updateAll
 
 	colorPickerRaster := newTappableRaster(createHueValueColorPickerPixelColor(picker.saturation))
-	colorPickerRaster.SetMinSize(pickerSize)
 	colorPickerRaster.tapped = func(p fyne.Position) {
-		picker.colorMarker.setPosition(p)
+		picker.hue = clamp01(float64(p.X / picker.pickerWidth))
+		picker.value = 1.0 - clamp01(float64(p.Y/picker.pickerHeight))
+		picker.updateHueValue()
+		picker.updatePickerColor()
+	}
+	colorPickerRaster.moved = func(dx, dy float64) {
+		picker.hue = clamp01(picker.hue + dx)
+		picker.value = clamp01(picker.value - dy)
+		picker.updateHueValue()
 		picker.updatePickerColor()
-		colorPickerRaster.Refresh()
 	}
-	colorPickerRaster.Resize(pickerSize) // Note: doesn't render if remove this line...
 	picker.colorPickerRaster = colorPickerRaster
 
-	saturationPickerRaster := newTappableRaster(createSaturationBarPicker(0., 1.))
-	saturationPickerRaster.SetMinSize(barSize)
-	saturationPickerRaster.tapped = func(p fyne.Position) {
-		picker.saturation = 1.0 - p.Y/barSize.Height
-		colorPickerRaster.setPixelColor(createHueValueColorPickerPixelColor(picker.saturation))
-		colorPickerRaster.Refresh()
-		setPositionY(picker.saturationMarker, p.Y)
+	picker.saturationBar = newPickerBar(o, true, createSaturationBarColor(0., 1.), picker.scrollStep, func(s float64) {
+		picker.saturation = s
+		picker.updateSaturation()
 		picker.updatePickerColor()
-	}
-	saturationPickerRaster.Resize(barSize)
-	picker.saturationPickerRaster = saturationPickerRaster
+	})
 
-	picker.alphaPickerBar = newAlphaPickerBar(barSize, picker.updatePickerColor)
+	picker.alphaPickerBar = newAlphaPickerBar(func(a float64) {
+		picker.alpha = a
+		picker.updatePickerColor()
+	}, picker.scrollStep, o)
 
-	picker.colorMarker = newDefaultMarker(5)
-	picker.saturationMarker = newDefaultBarMarker(picker.saturationBarWidth)
-	picker.saturationMarker.setPosition(fyne.NewPos(picker.saturationBarCenter(), 0))
+	picker.colorMarker = newStyledMarker(o.markerStyle)
 
-	picker.CanvasObject = newSpaceCenteredLayout(
+	picker.watchChanges(picker.Color, colorPickerRaster, picker.saturationBar.raster.tappableRaster, picker.alphaPickerBar.raster.tappableRaster)
+	picker.CanvasObject = o.newPickerLayout(
+		picker.resized,
+		picker.alphaPickerBar,
 		container.NewWithoutLayout(colorPickerRaster, picker.colorMarker.object()),
-		container.NewWithoutLayout(saturationPickerRaster, picker.saturationMarker.object()),
-		picker.alphaPickerBar.object(),
+		picker.saturationBar.object(),
 	)
+	picker.updateAll()
 	return picker
 }
 
+func (p *saturationColorPicker) resized(size float32) {
+	p.pickerWidth = size
+	p.pickerHeight = size
+	p.colorPickerRaster.Resize(fyne.NewSize(size, size))
+	p.saturationBar.resize(p.barSize(size))
+	p.alphaPickerBar.resize(p.barSize(size))
+	p.updateAll()
+}
+
+func (p *saturationColorPicker) updateAll() {
+	p.updateSaturation()
+	p.updateHueValue()
+	p.setAlpha(p.alpha)
+	p.alphaPickerBar.setColor(p.Color())
+}
+
+func (p *saturationColorPicker) updateSaturation() {
+	p.saturationBar.setValue(p.saturation)
+	p.setAreaInput(p.saturation, createHueValueColorPickerPixelColor)
+}
+
+func (p *saturationColorPicker) updateHueValue() {
+	x := float32(math.Round(float64(p.pickerWidth) * p.hue))
+	y := float32(math.Round(float64(p.pickerHeight) * (1.0 - p.value)))
+	p.colorMarker.setPosition(fyne.NewPos(x, y))
+	p.colorMarker.Refresh()
+
+	p.saturationBar.setBarColor(createSaturationBarColor(p.hue, p.value))
+}
+
 func (p *saturationColorPicker) updatePickerColor() {
-	x := p.colorMarker.position().X
-	y := p.colorMarker.position().Y
-	color := fromHSVA(float64(x)/float64(p.pickerWidth), float64(p.saturation), 1.0-float64(y)/float64(p.pickerHeight), float64(p.alpha))
-	p.changed(color)
+	color := p.Color()
+	p.fireChanged(color)
 
-	// TODO: should not recalculate...
-	h, s, v, _ := fromColor(color)
-	if s > 0 {
-		p.saturationPickerRaster.setPixelColor(createSaturationBarPicker(h, v))
-		p.saturationPickerRaster.Refresh()
+	p.alphaPickerBar.setColor(color)
+}
+
+type lightnessColorPicker struct {
+	*colorPickerBase
+
+	pickerWidth   float32
+	pickerHeight  float32
+	hslSaturation float64
+	lightness     float64
+	colorMarker   marker
+	hueBar        *pickerBar
+	*alphaPickerBar
+}
+
+func newLightnessColorPicker(o *options) ColorPicker {
+	picker := &lightnessColorPicker{
+		hslSaturation:   0,
+		lightness:       1,
+		colorPickerBase: newColorPickerBase(o),
 	}
+	picker.updateView = picker.updateAll
 
-	p.alphaPickerBar.setColor(color)
+	colorPickerRaster := newTappableRaster(createSaturationLightnessColorPickerPixelColor(picker.hue))
+	colorPickerRaster.tapped = func(p fyne.Position) {
+		picker.hslSaturation = clamp01(float64(p.X / picker.pickerWidth))
+		picker.lightness = 1.0 - clamp01(float64(p.Y/picker.pickerHeight))
+		picker.updateColorMarker()
+		picker.updatePickerColor()
+	}
+	colorPickerRaster.moved = func(dx, dy float64) {
+		picker.hslSaturation = clamp01(picker.hslSaturation + dx)
+		picker.lightness = clamp01(picker.lightness - dy)
+		picker.updateColorMarker()
+		picker.updatePickerColor()
+	}
+	picker.colorPickerRaster = colorPickerRaster
+
+	picker.hueBar = newPickerBar(o, false, hueBarColor, picker.scrollStep, func(h float64) {
+		picker.hue = h
+		picker.updateHue()
+		picker.updatePickerColor()
+	})
+
+	picker.alphaPickerBar = newAlphaPickerBar(func(a float64) {
+		picker.alpha = a
+		picker.updatePickerColor()
+	}, picker.scrollStep, o)
+
+	picker.colorMarker = newStyledMarker(o.markerStyle)
+
+	picker.watchChanges(picker.Color, colorPickerRaster, picker.hueBar.raster.tappableRaster, picker.alphaPickerBar.raster.tappableRaster)
+	picker.CanvasObject = o.newPickerLayout(
+		picker.resized,
+		picker.alphaPickerBar,
+		container.NewWithoutLayout(colorPickerRaster, picker.colorMarker.object()),
+		picker.hueBar.object(),
+	)
+	picker.updateAll()
+	return picker
 }
 
-func (p *saturationColorPicker) SetColor(c color.Color) {
-	h, s, v, a := fromColor(c)
-	p.saturation = float32(s)
-	setPositionY(p.saturationMarker, p.pickerHeight*(1.0-float32(s)))
-	p.colorPickerRaster.setPixelColor(createHueValueColorPickerPixelColor(p.saturation))
-	p.colorPickerRaster.Refresh()
-	x := float32(math.Round(float64(p.pickerWidth) * h))
-	y := float32(math.Round(float64(p.pickerHeight) * (1.0 - v)))
+func (p *lightnessColorPicker) resized(size float32) {
+	p.pickerWidth = size
+	p.pickerHeight = size
+	p.colorPickerRaster.Resize(fyne.NewSize(size, size))
+	p.hueBar.resize(p.barSize(size))
+	p.alphaPickerBar.resize(p.barSize(size))
+	p.updateAll()
+}
+
+func (p *lightnessColorPicker) Color() color.Color {
+	return fromHSLA(p.hue, p.hslSaturation, p.lightness, p.alpha)
+}
+
+func (p *lightnessColorPicker) HSVA() (h, s, v, a float64) {
+	s, v = hslToHSV(p.hslSaturation, p.lightness)
+	return p.hue, s, v, p.alpha
+}
+
+func (p *lightnessColorPicker) SetHSVA(h, s, v, a float64) {
+	if p.isFlattened(a) {
+		p.SetColor(fromHSVA(clamp01(h), clamp01(s), clamp01(v), clamp01(a)))
+		return
+	}
+	hslSaturation, lightness := hsvToHSL(clamp01(s), clamp01(v))
+	if lightness == 1 {
+		hslSaturation = p.hslSaturation
+	}
+	p.setHSLA(h, hslSaturation, lightness, a)
+}
+
+func (p *lightnessColorPicker) SetColor(c color.Color) {
+	h, s, l, a := toHSL(p.flatten(c))
+	// hue (and saturation if black or white) is undefined for achromatic colors, so keep the current one
+	if s == 0 {
+		h = p.hue
+	}
+	if l == 0 || l == 1 {
+		s = p.hslSaturation
+	}
+	p.setHSLA(h, s, l, a)
+}
+
+func (p *lightnessColorPicker) setHSLA(h, s, l, a float64) {
+	p.hue = clamp01(h)
+	p.hslSaturation = clamp01(s)
+	p.lightness = clamp01(l)
+	p.alpha = clamp01(a)
+	p.updateAll()
+	p.fireChanged(p.Color())
+}
+
+func (p *lightnessColorPicker) updateAll() {
+	p.updateHue()
+	p.updateColorMarker()
+	p.setAlpha(p.alpha)
+	p.alphaPickerBar.setColor(p.Color())
+}
+
+func (p *lightnessColorPicker) updateHue() {
+	p.hueBar.setValue(p.hue)
+	p.setAreaInput(p.hue, createSaturationLightnessColorPickerPixelColor)
+}
+
+func (p *lightnessColorPicker) updateColorMarker() {
+	x := float32(math.Round(float64(p.pickerWidth) * p.hslSaturation))
+	y := float32(math.Round(float64(p.pickerHeight) * (1.0 - p.lightness)))
 	p.colorMarker.setPosition(fyne.NewPos(x, y))
-	p.setAlpha(float32(a))
-	p.updatePickerColor()
+	p.colorMarker.Refresh()
 }
 
-func (p *saturationColorPicker) saturationBarCenter() float32 {
-	return float32(p.saturationBarWidth) / 2
+func (p *lightnessColorPicker) updatePickerColor() {
+	color := p.Color()
+	p.fireChanged(color)
+
+	p.alphaPickerBar.setColor(color)
 }
 
-type alphaPickerBar struct {
-	alpha  float32
-	marker barMarker
-	raster *tappableRaster
+type oklchColorPicker struct {
+	*colorPickerBase
 
-	barHeight float32
+	pickerWidth  float32
+	pickerHeight float32
+	lightness    float64
+	chroma       float64
+	// hsvHue and hsvSaturation are the HSV hue and saturation reported by HSVA while they are undefined,
+	// which are the last defined ones or the ones set by SetHSVA.
+	hsvHue        float64
+	hsvSaturation float64
+	colorMarker   marker
+	hueBar        *pickerBar
+	*alphaPickerBar
 }
 
-func newAlphaPickerBar(size fyne.Size, tapped func()) *alphaPickerBar {
-	bar := &alphaPickerBar{
-		alpha:     1.,
-		barHeight: size.Height,
+func newOKLCHColorPicker(o *options) ColorPicker {
+	picker := &oklchColorPicker{
+		lightness:       1,
+		chroma:          0,
+		colorPickerBase: newColorPickerBase(o),
 	}
+	picker.updateView = picker.updateAll
 
-	alphaPickerRaster := newTappableRaster(createAlphaBarPickerPixelColor(transparent))
-	alphaPickerRaster.SetMinSize(size)
-	alphaPickerRaster.tapped = func(p fyne.Position) {
-		bar.alpha = 1. - (p.Y / size.Height)
-		setPositionY(bar.marker, p.Y)
-		tapped()
+	colorPickerRaster := newTappableRaster(createChromaLightnessColorPickerPixelColor(picker.hue))
+	colorPickerRaster.tapped = func(p fyne.Position) {
+		picker.chroma = clamp01(float64(p.X/picker.pickerWidth)) * oklchMaxChroma
+		picker.lightness = 1.0 - clamp01(float64(p.Y/picker.pickerHeight))
+		picker.updateColorMarker()
+		picker.updatePickerColor()
+	}
+	colorPickerRaster.moved = func(dx, dy float64) {
+		picker.chroma = clamp01(picker.chroma/oklchMaxChroma+dx) * oklchMaxChroma
+		picker.lightness = clamp01(picker.lightness - dy)
+		picker.updateColorMarker()
+		picker.updatePickerColor()
 	}
-	alphaPickerRaster.Resize(size)
-	bar.raster = alphaPickerRaster
+	picker.colorPickerRaster = colorPickerRaster
+
+	picker.hueBar = newPickerBar(o, false, oklchHueBarColor, picker.scrollStep, func(h float64) {
+		picker.hue = h
+		picker.updateHue()
+		picker.updatePickerColor()
+	})
 
-	bar.marker = newDefaultBarMarker(size.Width)
-	bar.marker.setPosition(fyne.NewPos(float32(size.Width)/2, 0))
+	picker.alphaPickerBar = newAlphaPickerBar(func(a float64) {
+		picker.alpha = a
+		picker.updatePickerColor()
+	}, picker.scrollStep, o)
+
+	picker.colorMarker = newStyledMarker(o.markerStyle)
+
+	picker.watchChanges(picker.Color, colorPickerRaster, picker.hueBar.raster.tappableRaster, picker.alphaPickerBar.raster.tappableRaster)
+	picker.CanvasObject = o.newPickerLayout(
+		picker.resized,
+		picker.alphaPickerBar,
+		container.NewWithoutLayout(colorPickerRaster, picker.colorMarker.object()),
+		picker.hueBar.object(),
+	)
+	picker.updateAll()
+	return picker
+}
 
-	return bar
+func (p *oklchColorPicker) resized(size float32) {
+	p.pickerWidth = size
+	p.pickerHeight = size
+	p.colorPickerRaster.Resize(fyne.NewSize(size, size))
+	p.hueBar.resize(p.barSize(size))
+	p.alphaPickerBar.resize(p.barSize(size))
+	p.updateAll()
+}
+
+func (p *oklchColorPicker) Color() color.Color {
+	return fromOKLCHA(p.lightness, p.chroma, p.hue, p.alpha)
+}
+
+func (p *oklchColorPicker) HSVA() (h, s, v, a float64) {
+	h, s, v, a = fromColor(p.Color())
+	// hue (and saturation if black) is undefined for achromatic colors, so report the kept one
+	if s == 0 {
+		h = p.hsvHue
+	}
+	if v == 0 {
+		s = p.hsvSaturation
+	}
+	return h, s, v, a
+}
+
+func (p *oklchColorPicker) SetHSVA(h, s, v, a float64) {
+	p.hsvHue = clamp01(h)
+	p.hsvSaturation = clamp01(s)
+	p.SetColor(fromHSVA(clamp01(h), clamp01(s), clamp01(v), clamp01(a)))
+}
+
+func (p *oklchColorPicker) SetColor(c color.Color) {
+	l, ch, h, a := toOKLCH(p.flatten(c))
+	// hue (and chroma if black or white) is undefined for achromatic colors, so keep the current one
+	if ch < oklchAchromaticChroma {
+		h = p.hue
+		ch = 0
+	}
+	if l <= oklchAchromaticLightness || l >= 1-oklchAchromaticLightness {
+		ch = p.chroma
+	}
+	p.hue = clamp01(h)
+	p.chroma = math.Min(ch, oklchMaxChroma)
+	p.lightness = clamp01(l)
+	p.alpha = clamp01(a)
+	p.updateAll()
+	p.keepHSV()
+	p.fireChanged(p.Color())
+}
+
+// keepHSV keeps the HSV hue and saturation of the color while they are defined.
+func (p *oklchColorPicker) keepHSV() {
+	h, s, v, _ := fromColor(p.Color())
+	if s > 0 {
+		p.hsvHue = h
+	}
+	if v > 0 {
+		p.hsvSaturation = s
+	}
+}
+
+func (p *oklchColorPicker) updateAll() {
+	p.updateHue()
+	p.updateColorMarker()
+	p.setAlpha(p.alpha)
+	p.alphaPickerBar.setColor(p.Color())
+}
+
+func (p *oklchColorPicker) updateHue() {
+	p.hueBar.setValue(p.hue)
+	p.setAreaInput(p.hue, createChromaLightnessColorPickerPixelColor)
+}
+
+func (p *oklchColorPicker) updateColorMarker() {
+	x := float32(math.Round(float64(p.pickerWidth) * p.chroma / oklchMaxChroma))
+	y := float32(math.Round(float64(p.pickerHeight) * (1.0 - p.lightness)))
+	p.colorMarker.setPosition(fyne.NewPos(x, y))
+	p.colorMarker.Refresh()
+}
+
+func (p *oklchColorPicker) updatePickerColor() {
+	p.keepHSV()
+	color := p.Color()
+	p.fireChanged(color)
+
+	p.alphaPickerBar.setColor(color)
+}
+
+// alphaPickerBar is the bar to select alpha, displayed over a checkerboard.
+type alphaPickerBar struct {
+	*pickerBar
+	checkerboardColors [2]color.Color
+	// rgb is the color of the bar, which is regenerated only when it changes.
+	rgb [3]float64
+}
+
+func newAlphaPickerBar(tapped func(float64), scrollStep func() float64, o *options) *alphaPickerBar {
+	return &alphaPickerBar{
+		pickerBar:          newPickerBar(o, true, createAlphaBarColor(transparent), scrollStep, tapped),
+		checkerboardColors: o.checkerboardColors,
+	}
 }
 
 func (b *alphaPickerBar) object() fyne.CanvasObject {
 	return container.New(
 		layout.NewStackLayout(),
-		newCheckeredBackground(),
-		container.NewWithoutLayout(b.raster, b.marker.object()),
+		newCheckeredBackground(b.checkerboardColors[0], b.checkerboardColors[1]),
+		b.pickerBar.object(),
 	)
 }
 
 func (b *alphaPickerBar) setColor(c color.Color) {
-	b.raster.setPixelColor(createAlphaBarPickerPixelColor(c))
-	b.raster.Refresh()
+	r, g, bl, _ := toFloatRGBA(c)
+	if rgb := [3]float64{r, g, bl}; rgb != b.rgb {
+		b.rgb = rgb
+		b.setBarColor(createAlphaBarColor(c))
+	}
 }
 
-func (b *alphaPickerBar) setAlpha(a float32) {
-	b.alpha = a
-	setPositionY(b.marker, b.barHeight*(1.-a))
+func (b *alphaPickerBar) setAlpha(a float64) {
+	b.setValue(a)
 }
 
 type colorPickerBaseWidgetRender struct {
@@ -534,36 +1115,42 @@ func (r *colorPickerBaseWidgetRender) Objects() []fyne.CanvasObject {
 
 func (r *colorPickerBaseWidgetRender) Destroy() {}
 
-func createAlphaBarPickerPixelColor(c color.Color) func(int, int, int, int) color.Color {
-	return func(x, y, w, h int) color.Color {
-		r, g, b, _ := toFloatRGBA(c)
-		a := 1. - (float64(y) / float64(h))
+func createAlphaBarColor(c color.Color) barColorFunc {
+	r, g, b, _ := toFloatRGBA(c)
+	return func(a float64) color.NRGBA {
 		return fromFloatNRGBA(r, g, b, a)
 	}
 }
 
-func createSaturationValueColorPickerPixelColor(hue float32) func(int, int, int, int) color.Color {
-	return func(x, y, w, h int) color.Color {
-		return fromHSV(float64(hue), float64(x)/float64(w), 1.0-float64(y)/float64(h))
+func createSaturationValueColorPickerPixelColor(hue float64) pixelColorFunc {
+	return func(x, y, w, h int) color.NRGBA {
+		return fromHSV(hue, float64(x)/float64(w), 1.0-float64(y)/float64(h))
 	}
 }
 
-func hueBarPicker(x, y, w, h int) color.Color {
-	return fromHSV(float64(y)/float64(h), 1.0, 1.0)
+func hueBarColor(hue float64) color.NRGBA {
+	return fromHSV(hue, 1.0, 1.0)
 }
 
-func circleHuePicker(x, y, w, h int) color.Color {
-	return circleHuePickerFloat(float64(x), float64(y), float64(w), float64(h))
+// createCircleHuePickerPixelColor returns the hue ring whose width is relative to its diameter by barWidthRatio.
+func createCircleHuePickerPixelColor(barWidthRatio float32) pixelColorFunc {
+	return func(x, y, w, h int) color.NRGBA {
+		// sample at the center of the pixel so that the ring is symmetric
+		return circleHuePickerFloat(float64(x)+0.5, float64(y)+0.5, float64(w), float64(h), float64(barWidthRatio))
+	}
 }
 
-func circleHuePickerFloat(x, y, w, h float64) color.Color {
-	ir := w/2 - w/10
+// circleHuePickerFloat returns the color of the hue ring at (x, y) in pixels,
+// whose edges are anti-aliased by the coverage of the pixel.
+func circleHuePickerFloat(x, y, w, h, barWidthRatio float64) color.NRGBA {
+	ir := w/2 - w*barWidthRatio
 	or := w / 2
 	cx := w / 2
 	cy := h / 2
 
 	dist := distance(x, y, cx, cy)
-	if dist < ir || or < dist {
+	coverage := math.Min(edgeCoverage(or-dist), edgeCoverage(dist-ir))
+	if coverage <= 0 {
 		return transparent
 	}
 
@@ -571,76 +1158,217 @@ func circleHuePickerFloat(x, y, w, h float64) color.Color {
 	rad += math.Pi
 	hue := rad / (2 * math.Pi)
 
-	return fromHSV(hue, 1.0, 1.0)
+	return withCoverage(fromHSV(hue, 1.0, 1.0), coverage)
 }
 
-func createCircleHueSaturationColorPickerPixelColor(value float32) func(int, int, int, int) color.Color {
-	return func(x, y, w, h int) color.Color {
-		return calcColorFromCirclePointAndValue(float64(x), float64(y), float64(w)/2., float64(h)/2., float64(value))
+func createCircleHueSaturationColorPickerPixelColor(value float64) pixelColorFunc {
+	return func(x, y, w, h int) color.NRGBA {
+		return calcColorFromCirclePointAndValue(float64(x)+0.5, float64(y)+0.5, float64(w)/2., float64(h)/2., value)
 	}
 }
 
-func calcColorFromCirclePointAndValue(x, y, cx, cy, value float64) color.Color {
+// calcColorFromCirclePointAndValue returns the color of the circle area at (x, y) in pixels,
+// whose edge is anti-aliased by the coverage of the pixel.
+func calcColorFromCirclePointAndValue(x, y, cx, cy, value float64) color.NRGBA {
 	dist := distance(x, y, cx, cy)
-	if cx < dist {
+	coverage := edgeCoverage(cx - dist)
+	if coverage <= 0 {
 		return transparent
 	}
 
+	hue, saturation := calcHueSaturationFromCirclePoint(x, y, cx, cy)
+	return withCoverage(fromHSV(hue, saturation, value), coverage)
+}
+
+// edgeCoverage returns the approximate fraction of a pixel covered by a shape,
+// given the distance in pixels from the center of the pixel to the edge, positive inside the shape.
+func edgeCoverage(d float64) float64 {
+	return clamp01(d + 0.5)
+}
+
+func withCoverage(c color.NRGBA, coverage float64) color.NRGBA {
+	c.A = roundUint8(float64(c.A) * coverage)
+	return c
+}
+
+func calcHueSaturationFromCirclePoint(x, y, cx, cy float64) (float64, float64) {
+	dist := distance(x, y, cx, cy)
+
 	rad := math.Atan2(y-cy, cx-x)
 	rad += math.Pi
 	hue := rad / (2 * math.Pi)
+	if hue >= 1 {
+		hue = 0
+	}
 
-	return fromHSV(hue, dist/cx, value)
+	return hue, math.Min(dist/cx, 1)
 }
 
-func createValueBarPicker(hue, saturation float32) func(x, y, w, h int) color.Color {
-	return func(x, y, w, h int) color.Color {
-		return fromHSV(float64(hue), float64(saturation), 1.0-float64(y)/float64(h))
+func createValueBarColor(hue, saturation float64) barColorFunc {
+	return func(value float64) color.NRGBA {
+		return fromHSV(hue, saturation, value)
 	}
 }
 
-func createHueValueColorPickerPixelColor(saturation float32) func(int, int, int, int) color.Color {
-	return func(x, y, w, h int) color.Color {
-		return fromHSV(float64(x)/float64(w), float64(saturation), 1.0-float64(y)/float64(h))
+func createHueValueColorPickerPixelColor(saturation float64) pixelColorFunc {
+	return func(x, y, w, h int) color.NRGBA {
+		return fromHSV(float64(x)/float64(w), saturation, 1.0-float64(y)/float64(h))
 	}
 }
 
-func createSaturationBarPicker(hue, value float64) func(x, y, w, h int) color.Color {
-	return func(x, y, w, h int) color.Color {
-		return fromHSV(hue, 1.0-float64(y)/float64(h), value)
+func createSaturationBarColor(hue, value float64) barColorFunc {
+	return func(saturation float64) color.NRGBA {
+		return fromHSV(hue, saturation, value)
 	}
 }
 
-func newSpaceCenteredLayout(objects ...fyne.CanvasObject) *fyne.Container {
-	l := newSpacedLayout(
-		layout.NewVBoxLayout(),
-		newSpacedLayout(
-			layout.NewHBoxLayout(),
-			objects...,
-		),
+func createSaturationLightnessColorPickerPixelColor(hue float64) pixelColorFunc {
+	return func(x, y, w, h int) color.NRGBA {
+		return fromHSL(hue, float64(x)/float64(w), 1.0-float64(y)/float64(h))
+	}
+}
+
+func createChromaLightnessColorPickerPixelColor(hue float64) pixelColorFunc {
+	return func(x, y, w, h int) color.NRGBA {
+		l := 1.0 - float64(y)/float64(h)
+		lch := colorspace.OKLCh{L: l, C: float64(x) / float64(w) * oklchMaxChroma, H: hue * 360}
+		r, g, b := colorspace.OKLabToLinearSRGB(colorspace.OKLChToOKLab(lch))
+		r, g, b = colorspace.LinearToSRGB(r), colorspace.LinearToSRGB(g), colorspace.LinearToSRGB(b)
+		if colorspace.IsInSRGBGamut(lch) {
+			return fromFloatNRGBA(clamp01(r), clamp01(g), clamp01(b), 1)
+		}
+		return outOfGamutPixelColor(x, y, r, g, b)
+	}
+}
+
+func oklchHueBarColor(hue float64) color.NRGBA {
+	return fromOKLCHA(0.75, 0.12, hue, 1)
+}
+
+// outOfGamutPixelColor returns the clipped color with dimmed diagonal hatching.
+func outOfGamutPixelColor(x, y int, r, g, b float64) color.NRGBA {
+	const (
+		hatchWidth = 3
+		dim        = 0.5
+		gray       = 0.5
 	)
-	l.Resize(l.MinSize())
-	return l
+	r, g, b = clamp01(r), clamp01(g), clamp01(b)
+	if ((x+y)/hatchWidth)%2 == 0 {
+		r, g, b = r*dim+gray*(1-dim), g*dim+gray*(1-dim), b*dim+gray*(1-dim)
+	}
+	return fromFloatNRGBA(r, g, b, 1)
 }
 
-func newSpacedLayout(l fyne.Layout, objects ...fyne.CanvasObject) *fyne.Container {
-	c := container.New(l)
-	c.Add(layout.NewSpacer())
-	for _, o := range objects {
-		c.Add(o)
+// newPickerLayout lays out the area and the bars of the picker, followed by the alpha bar unless it is removed.
+func (o *options) newPickerLayout(resized func(float32), alpha *alphaPickerBar, area fyne.CanvasObject, bars ...fyne.CanvasObject) *fyne.Container {
+	objects := append([]fyne.CanvasObject{area}, bars...)
+	if o.alpha {
+		objects = append(objects, alpha.object())
+	}
+	ratios := []float32{1}
+	for range objects[1:] {
+		ratios = append(ratios, o.barWidthRatio)
 	}
-	c.Add(layout.NewSpacer())
+	return newPickerLayout(o.size, o.orientation == OrientationHorizontal, resized, ratios, objects...)
+}
+
+// pickerLayout lays out the columns of a picker side by side, or the rows one above another if it is horizontal,
+// scaled to fit the allocated size keeping the aspect ratio.
+// Each column is as high as the picker and as wide as the height multiplied by its ratio, and vice versa for rows.
+type pickerLayout struct {
+	minBreadth float32
+	horizontal bool
+	ratios     []float32
+	breadth    float32
+	resized    func(breadth float32)
+}
+
+func newPickerLayout(minBreadth float32, horizontal bool, resized func(float32), ratios []float32, objects ...fyne.CanvasObject) *fyne.Container {
+	c := container.New(&pickerLayout{
+		minBreadth: minBreadth,
+		horizontal: horizontal,
+		ratios:     ratios,
+		resized:    resized,
+	}, objects...)
+	c.Resize(c.MinSize())
 	return c
 }
 
-func newCheckeredBackground() *canvas.Raster {
-	return canvas.NewRasterWithPixels(func(x, y, _, _ int) color.Color {
-		const boxSize = 10
+func (l *pickerLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	// length is along the objects are placed, and breadth is across them
+	length, breadth := size.Width, size.Height
+	if l.horizontal {
+		length, breadth = breadth, length
+	}
+	padding := theme.Padding() * float32(len(objects)-1)
+	b := fyne.Min(breadth, (length-padding)/l.totalRatio())
+	// allow for the rounding error of the ratios so that the minimum size is laid out as is
+	b = float32(math.Floor(float64(fyne.Max(b, 0)) + 0.001))
+	along := float32(math.Round(float64(length-b*l.totalRatio()-padding) / 2))
+	across := float32(math.Round(float64(breadth-b) / 2))
+	for i, o := range objects {
+		d := b * l.ratios[i]
+		if l.horizontal {
+			o.Move(fyne.NewPos(across, along))
+			o.Resize(fyne.NewSize(b, d))
+		} else {
+			o.Move(fyne.NewPos(along, across))
+			o.Resize(fyne.NewSize(d, b))
+		}
+		along += d + theme.Padding()
+	}
+	if b != l.breadth {
+		l.breadth = b
+		l.resized(b)
+	}
+}
+
+func (l *pickerLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	padding := theme.Padding() * float32(len(objects)-1)
+	length := l.minBreadth*l.totalRatio() + padding
+	if l.horizontal {
+		return fyne.NewSize(l.minBreadth, length)
+	}
+	return fyne.NewSize(length, l.minBreadth)
+}
 
+func (l *pickerLayout) totalRatio() float32 {
+	var total float32
+	for _, r := range l.ratios {
+		total += r
+	}
+	return total
+}
+
+// checkeredBoxSize is the size of the boxes of the checkered background, in device independent units.
+const checkeredBoxSize = 10
+
+func newCheckeredBackground(c1, c2 color.Color) *canvas.Raster {
+	n1 := color.NRGBAModel.Convert(c1).(color.NRGBA)
+	n2 := color.NRGBAModel.Convert(c2).(color.NRGBA)
+	var img *image.NRGBA
+	var r *canvas.Raster
+	r = canvas.NewRaster(func(w, h int) image.Image {
+		if img == nil || img.Rect.Dx() != w || img.Rect.Dy() != h {
+			// the raster is generated in pixels, which are scaled from the size of the object
+			boxSize := checkeredBoxSize
+			if width := r.Size().Width; width > 0 {
+				boxSize = max(1, int(math.Round(float64(checkeredBoxSize)*float64(w)/float64(width))))
+			}
+			img = image.NewNRGBA(image.Rect(0, 0, w, h))
+			fillPixels(img, createCheckeredPixelColor(boxSize, n1, n2))
+		}
+		return img
+	})
+	return r
+}
+
+func createCheckeredPixelColor(boxSize int, c1, c2 color.NRGBA) pixelColorFunc {
+	return func(x, y, _, _ int) color.NRGBA {
 		if (x/boxSize)%2 == (y/boxSize)%2 {
-			return color.Gray{Y: 58}
+			return c1
 		}
 
-		return color.Gray{Y: 84}
-	})
+		return c2
+	}
 }
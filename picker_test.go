@@ -0,0 +1,522 @@
+package colorpicker
+
+import (
+	"image/color"
+	"math"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+)
+
+var allStyles = []PickerStyle{StyleHue, StyleHueCircle, StyleValue, StyleSaturation, StyleLightness, StyleOKLCH}
+
+func TestPickerSetHSVA(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := New(200, style)
+		var changed color.Color
+		p.SetOnChanged(func(c color.Color) {
+			changed = c
+		})
+		p.SetHSVA(0.25, 0.5, 0.75, 0.5)
+
+		h, s, v, a := p.HSVA()
+		// styles not based on HSV derive HSVA from the 8 bit color
+		if notEqualsUint8(h, 0.25) || notEqualsUint8(s, 0.5) || notEqualsUint8(v, 0.75) || notEqualsUint8(a, 0.5) {
+			t.Errorf("style %d: HSVA() = %f, %f, %f, %f; want 0.25, 0.5, 0.75, 0.5", style, h, s, v, a)
+		}
+		want := fromHSVA(0.25, 0.5, 0.75, 0.5)
+		if got := p.Color(); got != want {
+			t.Errorf("style %d: Color() = %v; want %v", style, got, want)
+		}
+		if changed != want {
+			t.Errorf("style %d: changed with %v; want %v", style, changed, want)
+		}
+	}
+}
+
+func TestPickerSetColorKeepsHue(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := New(200, style)
+		p.SetColor(color.NRGBA{0x00, 0x80, 0xff, 0xff})
+		wantH, wantS, _, _ := p.HSVA()
+
+		p.SetColor(color.NRGBA{0x00, 0x00, 0x00, 0xff})
+		h, s, v, _ := p.HSVA()
+		if notEquals(h, wantH) || notEquals(s, wantS) || v != 0 {
+			t.Errorf("style %d: HSVA() after black = %f, %f, %f; want %f, %f, 0", style, h, s, v, wantH, wantS)
+		}
+
+		p.SetColor(color.NRGBA{0x80, 0x80, 0x80, 0xff})
+		h, s, _, _ = p.HSVA()
+		if notEquals(h, wantH) || s != 0 {
+			t.Errorf("style %d: HSVA() after gray = %f, %f; want %f, 0", style, h, s, wantH)
+		}
+	}
+}
+
+func TestPickerSetHSVAKeepsHue(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := New(200, style)
+		p.SetHSVA(0.25, 0.5, 0, 1)
+		if h, s, v, _ := p.HSVA(); notEqualsUint8(h, 0.25) || notEqualsUint8(s, 0.5) || v != 0 {
+			t.Errorf("style %d: HSVA() after black = %f, %f, %f; want 0.25, 0.5, 0", style, h, s, v)
+		}
+
+		p.SetHSVA(0.75, 0, 0.5, 1)
+		if h, s, _, _ := p.HSVA(); notEqualsUint8(h, 0.75) || s != 0 {
+			t.Errorf("style %d: HSVA() after gray = %f, %f; want 0.75, 0", style, h, s)
+		}
+	}
+}
+
+func notEqualsUint8(f1, f2 float64) bool {
+	return math.Abs(f1-f2) > 1./255.
+}
+
+func TestPickerKeyboard(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := New(200, style)
+		w := test.NewWindow(p)
+		p.SetHSVA(0.5, 0.5, 0.5, 0.5)
+
+		rasters := focusRasters(t, w.Canvas())
+		focus := test.WidgetRenderer(rasters[0].(fyne.Widget)).(*rasterWidgetRender).focus
+		if !focus.Visible() || test.WidgetRenderer(rasters[1].(fyne.Widget)).(*rasterWidgetRender).focus.Visible() {
+			t.Errorf("style %d: focus ring is not drawn only on the focused raster", style)
+		}
+
+		for i, r := range rasters[:2] {
+			before := p.Color()
+			r.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+			if p.Color() == before {
+				t.Errorf("style %d: raster %d did not change the color by down key", style, i)
+			}
+		}
+
+		alpha := rasters[2].(*scrollableRaster)
+		alpha.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+		if _, _, _, a := p.HSVA(); notEqualsUint8(a, 0.51) {
+			t.Errorf("style %d: alpha after up = %f; want 0.51", style, a)
+		}
+		alpha.KeyDown(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+		alpha.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+		alpha.KeyUp(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+		if _, _, _, a := p.HSVA(); notEqualsUint8(a, 0.41) {
+			t.Errorf("style %d: alpha after shift+down = %f; want 0.41", style, a)
+		}
+		alpha.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEnd})
+		if _, _, _, a := p.HSVA(); a != 0 {
+			t.Errorf("style %d: alpha after end = %f; want 0", style, a)
+		}
+		alpha.TypedKey(&fyne.KeyEvent{Name: fyne.KeyHome})
+		if _, _, _, a := p.HSVA(); a != 1 {
+			t.Errorf("style %d: alpha after home = %f; want 1", style, a)
+		}
+		w.Close()
+	}
+}
+
+func TestPickerScroll(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := New(200, style)
+		w := test.NewWindow(p)
+		p.SetHSVA(0.5, 0.5, 0.5, 0.5)
+
+		var area *tappableRaster
+		var bars []fyne.Scrollable
+		for _, r := range focusRasters(t, w.Canvas()) {
+			switch r := r.(type) {
+			case *tappableRaster:
+				area = r
+			case fyne.Scrollable:
+				bars = append(bars, r)
+			}
+		}
+		if area == nil || len(bars) != 2 {
+			t.Fatalf("style %d: area %v and %d scrollable bars; want the area and 2 bars", style, area, len(bars))
+		}
+		bar, alpha := bars[0], bars[1]
+		before := p.Color()
+		bar.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.NewDelta(0, -scrollNotchDelta)})
+		if p.Color() == before {
+			t.Errorf("style %d: scrolling the bar did not change the color", style)
+		}
+
+		alpha.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.NewDelta(0, scrollNotchDelta)})
+		if _, _, _, a := p.HSVA(); notEqualsUint8(a, 0.51) {
+			t.Errorf("style %d: alpha after scroll up = %f; want 0.51", style, a)
+		}
+		p.SetScrollStep(0.1)
+		alpha.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.NewDelta(0, -scrollNotchDelta/2)})
+		if _, _, _, a := p.HSVA(); notEqualsUint8(a, 0.46) {
+			t.Errorf("style %d: alpha after scroll down = %f; want 0.46", style, a)
+		}
+		w.Close()
+	}
+}
+
+func TestCircleHueColorPickerScroll(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHueCircle).(*circleHueColorPicker)
+	p.SetHSVA(0.5, 1, 1, 1)
+	ring := test.NewWindow(p).Canvas()
+	// the ring is drawn beneath the area, so it is focused first
+	bar := focusRasters(t, ring)[0].(*scrollableRaster)
+	before := p.hueMarker.position()
+
+	bar.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.NewDelta(0, -scrollNotchDelta*10)})
+	if h, _, _, _ := p.HSVA(); notEquals(h, 0.6) {
+		t.Errorf("hue after scroll = %f; want 0.6", h)
+	}
+	// the marker rotates around the ring
+	after := p.hueMarker.position()
+	cx, cy := float64(p.hueCircleWidth)/2, float64(p.hueCircleWidth)/2
+	r1 := distance(float64(before.X), float64(before.Y), cx, cy)
+	r2 := distance(float64(after.X), float64(after.Y), cx, cy)
+	if before == after || math.Abs(r1-r2) > 1 {
+		t.Errorf("marker moved from %v to %v; want rotation around (%f, %f)", before, after, cx, cy)
+	}
+
+	// the hue wraps around the ring
+	p.SetHSVA(0.95, 1, 1, 1)
+	bar.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.NewDelta(0, -scrollNotchDelta*10)})
+	if h, _, _, _ := p.HSVA(); notEquals(h, 0.05) {
+		t.Errorf("hue after scroll past red = %f; want 0.05", h)
+	}
+	p.SetHSVA(0.01, 1, 1, 1)
+	bar.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	bar.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if h, _, _, _ := p.HSVA(); notEquals(h, 0.99) {
+		t.Errorf("hue after up keys past red = %f; want 0.99", h)
+	}
+	bar.TypedKey(&fyne.KeyEvent{Name: fyne.KeyHome})
+	if h, _, _, _ := p.HSVA(); h != 0 {
+		t.Errorf("hue after home = %f; want 0", h)
+	}
+}
+
+// focusRasters returns the area, the bar and the alpha bar of the picker in c in the order of Tab,
+// checking that Tab cycles between them. The alpha bar is always the last.
+func focusRasters(t *testing.T, c fyne.Canvas) []fyne.Focusable {
+	t.Helper()
+	var rasters []fyne.Focusable
+	for i := 0; i < 4; i++ {
+		c.FocusNext()
+		rasters = append(rasters, c.Focused())
+	}
+	if rasters[0] == rasters[1] || rasters[1] == rasters[2] || rasters[3] != rasters[0] {
+		t.Fatalf("focus order = %v", rasters)
+	}
+	return rasters[:3]
+}
+
+func TestDefaultHueColorPickerKeyboard(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue).(*defaultHueColorPicker)
+	p.SetHSVA(0.5, 0.5, 0.5, 1)
+	area := p.colorPickerRaster
+
+	area.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	area.KeyDown(&fyne.KeyEvent{Name: desktop.KeyShiftRight})
+	area.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if h, s, v, _ := p.HSVA(); h != 0.5 || notEquals(s, 0.51) || notEquals(v, 0.6) {
+		t.Errorf("HSVA() = %f, %f, %f; want 0.5, 0.51, 0.6", h, s, v)
+	}
+	area.FocusLost()
+	area.TypedKey(&fyne.KeyEvent{Name: fyne.KeyLeft})
+	if _, s, _, _ := p.HSVA(); notEquals(s, 0.5) {
+		t.Errorf("saturation after focus lost and left = %f; want 0.5", s)
+	}
+	area.TypedKey(&fyne.KeyEvent{Name: fyne.KeyHome})
+	if _, s, v, _ := p.HSVA(); s != 0 || v != 1 {
+		t.Errorf("HSVA() after home = _, %f, %f; want 0, 1", s, v)
+	}
+	area.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEnd})
+	if _, s, v, _ := p.HSVA(); s != 1 || v != 0 {
+		t.Errorf("HSVA() after end = _, %f, %f; want 1, 0", s, v)
+	}
+}
+
+func TestPickerResize(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := New(100, style)
+		if got := p.MinSize(); got.Height != 100 || got.Width <= 100 {
+			t.Errorf("style %d: MinSize() = %v; want height 100", style, got)
+		}
+		w := test.NewWindow(container.NewBorder(nil, nil, nil, nil, p))
+		w.SetPadded(false)
+		w.Resize(fyne.NewSize(800, 400))
+		if got := p.Size(); got != fyne.NewSize(800, 400) {
+			t.Errorf("style %d: Size() = %v; want the allocated size", style, got)
+		}
+		w.Close()
+	}
+}
+
+func TestDefaultHueColorPickerResize(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(100, StyleHue).(*defaultHueColorPicker)
+	p.SetHSVA(0.5, 1, 0.25, 0.5)
+	p.Resize(fyne.NewSize(800, 400))
+
+	if got := p.colorPickerRaster.Size(); got != fyne.NewSize(400, 400) {
+		t.Errorf("area size = %v; want 400x400", got)
+	}
+	if got := p.hueBar.raster.Size(); got != fyne.NewSize(40, 400) {
+		t.Errorf("bar size = %v; want 40x400", got)
+	}
+	if got := p.colorMarker.position(); got != fyne.NewPos(400, 300) {
+		t.Errorf("color marker = %v; want (400, 300)", got)
+	}
+	if got := p.hueBar.marker.position(); got != fyne.NewPos(20, 200) {
+		t.Errorf("hue marker = %v; want (20, 200)", got)
+	}
+	if got := p.alphaPickerBar.marker.position(); got != fyne.NewPos(20, 200) {
+		t.Errorf("alpha marker = %v; want (20, 200)", got)
+	}
+
+	// the area is centered keeping its aspect ratio
+	if pos := p.CanvasObject.(*fyne.Container).Objects[0].Position(); pos.Y != 0 || pos.X <= 0 {
+		t.Errorf("area position = %v; want centered horizontally", pos)
+	}
+	test.TapAt(p.colorPickerRaster, fyne.NewPos(100, 100))
+	if _, s, v, _ := p.HSVA(); s != 0.25 || v != 0.75 {
+		t.Errorf("HSVA() after tap = _, %f, %f; want 0.25, 0.75", s, v)
+	}
+}
+
+func TestCircleHueColorPickerResize(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(100, StyleHueCircle).(*circleHueColorPicker)
+	p.SetHSVA(0.5, 1, 1, 1)
+	p.Resize(fyne.NewSize(800, 400))
+
+	if got := p.circleHuePickerRaster.Size(); got != fyne.NewSize(400, 400) {
+		t.Errorf("ring size = %v; want 400x400", got)
+	}
+	// the marker is on the ring at hue 0.5, that is the left side
+	if got := p.hueMarker.position(); notEquals(float64(got.X), 20) || notEquals(float64(got.Y), 200) {
+		t.Errorf("hue marker = %v; want (20, 200)", got)
+	}
+	area := p.colorPickerRaster.Size().Width
+	if got := p.colorPickerArea.Position(); got.X != got.Y || math.Abs(float64(got.X*2+area-400)) > 1 {
+		t.Errorf("area position = %v; want centered in the ring", got)
+	}
+}
+
+func TestCircleHueColorPickerBarWidthRatio(t *testing.T) {
+	test.NewTempApp(t)
+	p := NewWithOptions(StyleHueCircle, WithSize(400), WithBarWidthRatio(0.2)).(*circleHueColorPicker)
+	p.SetHSVA(0.5, 1, 1, 1)
+	size := float64(p.hueCircleWidth)
+
+	// the marker is at the middle of the ring, which is a fifth of the size wide
+	if got := p.hueMarker.position(); notEquals(float64(got.X), size*0.1) || notEquals(float64(got.Y), size/2) {
+		t.Errorf("hue marker = %v; want (%f, %f)", got, size*0.1, size/2)
+	}
+	ring := p.circleHuePickerRaster.pixelColor
+	for _, tc := range []struct {
+		y      int
+		opaque bool
+	}{
+		{395, true},
+		{325, true},
+		{315, false}, // inside the inner radius of 120
+		{200, false},
+	} {
+		if got := ring(200, tc.y, 400, 400).A == 0xff; got != tc.opaque {
+			t.Errorf("ring at (200, %d) opaque = %v; want %v", tc.y, got, tc.opaque)
+		}
+	}
+	// the corners of the area are inside the ring
+	if corner := float64(p.pickerWidth) / math.Sqrt2; corner > size*0.3 {
+		t.Errorf("area corner is %f away from the center; want within the inner radius %f", corner, size*0.3)
+	}
+}
+
+type changeEvents struct {
+	started, changed, ended, committed []color.Color
+}
+
+func watchChangeEvents(p ColorPicker) *changeEvents {
+	e := &changeEvents{}
+	p.SetOnChangeStarted(func(c color.Color) { e.started = append(e.started, c) })
+	p.SetOnChanged(func(c color.Color) { e.changed = append(e.changed, c) })
+	p.SetOnChangeEnded(func(c color.Color) { e.ended = append(e.ended, c) })
+	p.SetOnCommitted(func(c color.Color) { e.committed = append(e.committed, c) })
+	return e
+}
+
+func TestPickerDragEvents(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := New(200, style)
+		w := test.NewWindow(p)
+		p.SetHSVA(0.5, 0.5, 0.5, 0.5)
+		before := p.Color()
+		e := watchChangeEvents(p)
+
+		var area *tappableRaster
+		for _, r := range focusRasters(t, w.Canvas()) {
+			if r, ok := r.(*tappableRaster); ok {
+				area = r
+			}
+		}
+		for i := 0; i < 5; i++ {
+			area.Dragged(&fyne.DragEvent{
+				PointEvent: fyne.PointEvent{Position: fyne.NewPos(60+float32(i)*10, 55)},
+				Dragged:    fyne.NewDelta(10, 0),
+			})
+		}
+		area.DragEnd()
+
+		if len(e.started) != 1 || e.started[0] != before {
+			t.Errorf("style %d: started %v; want once with %v", style, e.started, before)
+		}
+		if len(e.changed) != 5 {
+			t.Errorf("style %d: changed %d times; want 5", style, len(e.changed))
+		}
+		if len(e.ended) != 1 || e.ended[0] != p.Color() {
+			t.Errorf("style %d: ended %v; want once with %v", style, e.ended, p.Color())
+		}
+		if len(e.committed) != 1 || e.committed[0] != p.Color() {
+			t.Errorf("style %d: committed %v; want once with %v", style, e.committed, p.Color())
+		}
+		w.Close()
+	}
+}
+
+func TestPickerTapAndKeyEvents(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue).(*defaultHueColorPicker)
+	w := test.NewWindow(p)
+	defer w.Close()
+	e := watchChangeEvents(p)
+
+	test.Drag(w.Canvas(), fyne.NewPos(50, 50), 5, 5)
+	// the scroll gesture ends when the tap starts
+	p.hueBar.raster.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.NewDelta(0, -scrollNotchDelta)})
+	test.TapAt(p.hueBar.raster, fyne.NewPos(5, 50))
+	p.alphaPickerBar.raster.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	// moving beyond the extreme does not change the color, so it is not committed
+	p.alphaPickerBar.raster.TypedKey(&fyne.KeyEvent{Name: fyne.KeyHome})
+	p.alphaPickerBar.raster.TypedKey(&fyne.KeyEvent{Name: fyne.KeyHome})
+	// setting the color programmatically is not a change by the user
+	p.SetColor(color.Black)
+
+	if len(e.started) != 6 || len(e.ended) != 6 {
+		t.Errorf("started %d and ended %d times; want 6", len(e.started), len(e.ended))
+	}
+	if len(e.committed) != 5 {
+		t.Errorf("committed %d times; want 5", len(e.committed))
+	}
+	if len(e.changed) != 7 {
+		t.Errorf("changed %d times; want 7", len(e.changed))
+	}
+}
+
+func TestPickerScrollGesture(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue).(*defaultHueColorPicker)
+	p.SetHSVA(0.5, 1, 1, 1)
+	started := 0
+	p.SetOnChangeStarted(func(color.Color) { started++ })
+	committed := make(chan color.Color, 10)
+	p.SetOnCommitted(func(c color.Color) { committed <- c })
+
+	for i := 0; i < 5; i++ {
+		p.hueBar.raster.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.NewDelta(0, -scrollNotchDelta)})
+	}
+	if started != 1 || len(committed) != 0 {
+		t.Errorf("started %d and committed %d times while scrolling; want started once", started, len(committed))
+	}
+	select {
+	case c := <-committed:
+		if want := fromHSVA(0.55, 1, 1, 1); c != want {
+			t.Errorf("committed %v; want %v", c, want)
+		}
+	case <-time.After(scrollEndDelay * 5):
+		t.Fatal("the scroll gesture is not committed")
+	}
+	time.Sleep(scrollEndDelay * 2)
+	if len(committed) != 0 {
+		t.Errorf("committed %d more times after the scroll gesture", len(committed))
+	}
+}
+
+func TestPickerDisable(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := New(200, style)
+		w := test.NewWindow(p)
+		p.SetHSVA(0.5, 0.5, 0.5, 0.5)
+		before := p.Color()
+		rasters := focusRasters(t, w.Canvas())
+		e := watchChangeEvents(p)
+
+		p.Disable()
+		if !p.Disabled() {
+			t.Errorf("style %d: Disabled() = false after Disable", style)
+		}
+		for _, f := range rasters {
+			r := f.(fyne.Disableable)
+			if !r.Disabled() {
+				t.Errorf("style %d: raster %T is not disabled", style, r)
+			}
+			if c := f.(desktop.Cursorable).Cursor(); c != desktop.DefaultCursor {
+				t.Errorf("style %d: Cursor() = %v while disabled; want the default cursor", style, c)
+			}
+			test.TapAt(f.(fyne.Tappable), fyne.NewPos(60, 55))
+			f.TypedKey(&fyne.KeyEvent{Name: fyne.KeyHome})
+			if s, ok := f.(fyne.Scrollable); ok {
+				s.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.NewDelta(0, scrollNotchDelta)})
+			}
+		}
+		if got := p.Color(); got != before || len(e.started) != 0 {
+			t.Errorf("style %d: Color() = %v and started %d times while disabled; want %v without changes", style, got, len(e.started), before)
+		}
+		w.Canvas().Unfocus()
+		w.Canvas().FocusNext()
+		if f := w.Canvas().Focused(); f != nil {
+			t.Errorf("style %d: %T is focused while disabled", style, f)
+		}
+
+		// the color can still be set programmatically
+		p.SetColor(color.Black)
+		if got := p.Color(); !colorEquals(got, color.Black) {
+			t.Errorf("style %d: Color() = %v after SetColor while disabled; want black", style, got)
+		}
+
+		p.Enable()
+		test.TapAt(rasters[0].(fyne.Tappable), fyne.NewPos(60, 55))
+		if len(e.started) != 1 {
+			t.Errorf("style %d: started %d times after Enable; want once", style, len(e.started))
+		}
+		w.Close()
+	}
+}
+
+func TestDisabledPixelColor(t *testing.T) {
+	red := disabledPixelColor(func(int, int, int, int) color.NRGBA {
+		return color.NRGBA{R: 0xff, A: 0x80}
+	})(0, 0, 1, 1)
+	if red.R != red.G || red.G != red.B || red.A != 0x80 {
+		t.Errorf("disabled red = %v; want gray with the same alpha", red)
+	}
+	white := disabledPixelColor(func(int, int, int, int) color.NRGBA {
+		return color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	})(0, 0, 1, 1)
+	if white.R >= 0xff || white.R <= red.R {
+		t.Errorf("disabled white = %v; want dimmed and lighter than %v", white, red)
+	}
+}
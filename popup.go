@@ -0,0 +1,143 @@
+package colorpicker
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// keyHandlable is implemented by the pickers of this package.
+type keyHandlable interface {
+	addKeyHandler(func(*fyne.KeyEvent))
+}
+
+// NewColorSelectPopUpRect returns a rectangle that can be tapped to open a color picker in a pop-up next to it.
+// The color is applied while it is selected, and committed when the pop-up is closed
+// by tapping outside of it or pressing Escape.
+func NewColorSelectPopUpRect(parent fyne.Window, minSize fyne.Size, defaultColor color.Color) PickerOpenWidget {
+	rect := NewColorSelectModalRect(parent, minSize, defaultColor).(*colorSelectModalRect)
+	rect.tappableRect.tapped = rect.showPopUp
+	return rect
+}
+
+func (r *colorSelectModalRect) showPopUp(*fyne.PointEvent) {
+	from := r.color()
+	picker := NewWithOptions(r.pickerStyle, WithSize(colorSelectModalPickerDefaultSize), WithInitialColor(from))
+	picker.SetOnChanged(func(c color.Color) {
+		r.change(c, r.SetColor)
+	})
+	p := newPickerPopUp(picker, r, r.parent.Canvas())
+	p.closed = func() {
+		r.commit(from, r.color())
+	}
+	p.show()
+}
+
+// pickerPopUp is a widget.PopUp that displays a picker next to the anchor, flipped to the other side of it
+// if it does not fit in the canvas. It is closed by tapping outside of the picker or pressing Escape.
+// It is added to the overlays by itself instead of PopUp.Show, so that it receives the taps and keys and knows when it is closed.
+type pickerPopUp struct {
+	widget.PopUp
+
+	anchor fyne.CanvasObject
+	// pos is the position of the pop-up, excluding the shadow.
+	pos   fyne.Position
+	shown bool
+
+	closed func()
+}
+
+func newPickerPopUp(picker ColorPicker, anchor fyne.CanvasObject, c fyne.Canvas) *pickerPopUp {
+	p := &pickerPopUp{
+		// the picker moves its content instead of itself, so it is kept at the origin of a container
+		PopUp:  widget.PopUp{Content: container.NewStack(picker), Canvas: c},
+		anchor: anchor,
+		closed: func() {},
+	}
+	if h, ok := picker.(keyHandlable); ok {
+		// the keys are typed to the picker instead of the pop-up once the picker has focus
+		h.addKeyHandler(p.TypedKey)
+	}
+	p.ExtendBaseWidget(p)
+	return p
+}
+
+func (p *pickerPopUp) show() {
+	p.Canvas.Overlays().Add(p)
+	p.shown = true
+	p.place()
+	p.Canvas.Focus(p)
+}
+
+func (p *pickerPopUp) hide() {
+	if !p.shown {
+		return
+	}
+	p.shown = false
+	p.Canvas.Overlays().Remove(p)
+	p.closed()
+}
+
+// place moves the pop-up next to the anchor.
+func (p *pickerPopUp) place() {
+	size := p.MinSize()
+	p.pos = p.popUpPosition(size)
+	p.PopUp.Resize(size)
+	p.PopUp.Move(p.pos)
+}
+
+// Resize places the pop-up again when the canvas is resized, as the overlays are resized to the canvas.
+func (p *pickerPopUp) Resize(fyne.Size) {
+	p.place()
+}
+
+// popUpPosition returns the position of the pop-up of size, which is below the anchor aligned to its left edge,
+// or above it or aligned to its right edge if it does not fit in the canvas.
+func (p *pickerPopUp) popUpPosition(size fyne.Size) fyne.Position {
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(p.anchor)
+	anchor := p.anchor.Size()
+	bounds := p.Canvas.Size()
+	gap := theme.Padding()
+
+	x, y := pos.X, pos.Y+anchor.Height+gap
+	if x+size.Width > bounds.Width {
+		x = pos.X + anchor.Width - size.Width
+	}
+	if y+size.Height > bounds.Height && pos.Y-gap-size.Height >= 0 {
+		y = pos.Y - gap - size.Height
+	}
+	x = max(0, min(x, bounds.Width-size.Width))
+	y = max(0, min(y, bounds.Height-size.Height))
+	return fyne.NewPos(x, y)
+}
+
+func (p *pickerPopUp) isInside(pos fyne.Position) bool {
+	size := p.MinSize()
+	return p.pos.X <= pos.X && pos.X <= p.pos.X+size.Width &&
+		p.pos.Y <= pos.Y && pos.Y <= p.pos.Y+size.Height
+}
+
+func (p *pickerPopUp) Tapped(e *fyne.PointEvent) {
+	if !p.isInside(e.Position) {
+		p.hide()
+	}
+}
+
+func (p *pickerPopUp) TappedSecondary(e *fyne.PointEvent) {
+	p.Tapped(e)
+}
+
+func (p *pickerPopUp) FocusGained() {}
+
+func (p *pickerPopUp) FocusLost() {}
+
+func (p *pickerPopUp) TypedRune(rune) {}
+
+func (p *pickerPopUp) TypedKey(e *fyne.KeyEvent) {
+	if e.Name == fyne.KeyEscape {
+		p.hide()
+	}
+}
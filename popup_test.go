@@ -0,0 +1,125 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+)
+
+func openPickerPopUp(t *testing.T, w fyne.Window, rect *colorSelectModalRect) *pickerPopUp {
+	t.Helper()
+	test.Tap(rect)
+	p, ok := w.Canvas().Overlays().Top().(*pickerPopUp)
+	if !ok {
+		t.Fatalf("overlay = %T; want the picker pop-up", w.Canvas().Overlays().Top())
+	}
+	return p
+}
+
+func TestColorSelectPopUpRectPosition(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	w.SetPadded(false)
+	w.Resize(fyne.NewSize(800, 800))
+	rect := NewColorSelectPopUpRect(w, fyne.NewSize(20, 20), color.White).(*colorSelectModalRect)
+	w.SetContent(container.NewWithoutLayout(rect))
+	rect.Resize(fyne.NewSize(20, 20))
+
+	tests := []struct {
+		anchor fyne.Position
+		// below is true if the picker is below the anchor, and left is true if it is aligned to the left edge of the anchor.
+		below, left bool
+	}{
+		{fyne.NewPos(10, 10), true, true},
+		{fyne.NewPos(770, 10), true, false},
+		{fyne.NewPos(10, 770), false, true},
+		{fyne.NewPos(770, 770), false, false},
+	}
+	for _, tt := range tests {
+		rect.Move(tt.anchor)
+		p := openPickerPopUp(t, w, rect)
+		pos, size := p.pos, p.MinSize()
+		if c := p.Content.Position(); c.X < pos.X || c.Y < pos.Y || c.X+p.Content.Size().Width > pos.X+size.Width {
+			t.Errorf("anchor %v: picker at %v; want inside the pop-up at %v", tt.anchor, c, pos)
+		}
+		anchor := fyne.CurrentApp().Driver().AbsolutePositionForObject(rect)
+		if got := pos.Y > anchor.Y; got != tt.below {
+			t.Errorf("anchor %v: picker at %v below = %v; want %v", tt.anchor, pos, got, tt.below)
+		}
+		if tt.left && pos.X != anchor.X || !tt.left && pos.X+size.Width != anchor.X+20 {
+			t.Errorf("anchor %v: picker at %v; want aligned to the %s edge", tt.anchor, pos, map[bool]string{true: "left", false: "right"}[tt.left])
+		}
+		p.hide()
+	}
+}
+
+func TestColorSelectPopUpRectClose(t *testing.T) {
+	test.NewTempApp(t)
+	w := test.NewWindow(nil)
+	defer w.Close()
+	w.Resize(fyne.NewSize(800, 800))
+	original := color.NRGBA{R: 0xff, A: 0xff}
+	rect := NewColorSelectPopUpRect(w, fyne.NewSize(20, 20), original).(*colorSelectModalRect)
+	w.SetContent(container.NewWithoutLayout(rect))
+	rect.Resize(fyne.NewSize(20, 20))
+	var changed []color.Color
+	rect.SetOnChange(func(c color.Color) { changed = append(changed, c) })
+	var committed []color.Color
+	rect.addCommitListener(func(_, to color.Color) { committed = append(committed, to) })
+
+	// tapping inside the picker does not close it
+	p := openPickerPopUp(t, w, rect)
+	test.TapAt(p, p.pos.Add(fyne.NewPos(1, 1)))
+	if w.Canvas().Overlays().Top() != p {
+		t.Fatal("the pop-up is closed by tapping inside it")
+	}
+	picker := findPicker(p.Content)
+	picker.SetColor(color.Black)
+	if got := rect.color(); !colorEquals(got, color.Black) || len(changed) != 1 {
+		t.Errorf("rect color = %v and changed %v while open; want black once", got, changed)
+	}
+	test.TapAt(p, fyne.NewPos(790, 790))
+	if w.Canvas().Overlays().Top() != nil {
+		t.Error("the pop-up is not closed by tapping outside of it")
+	}
+	if len(committed) != 1 || !colorEquals(committed[0], color.Black) {
+		t.Errorf("committed %v; want black once", committed)
+	}
+
+	// Escape closes it even if the picker has focus
+	p = openPickerPopUp(t, w, rect)
+	picker = findPicker(p.Content)
+	picker.SetColor(original)
+	picker.(*defaultHueColorPicker).colorPickerRaster.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	if w.Canvas().Overlays().Top() != nil {
+		t.Error("the pop-up is not closed by Escape")
+	}
+	if len(committed) != 2 || !colorEquals(committed[1], original) {
+		t.Errorf("committed %v; want %v at last", committed, original)
+	}
+
+	// closing without changes does not commit
+	p = openPickerPopUp(t, w, rect)
+	p.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	if len(committed) != 2 {
+		t.Errorf("committed %d times; want 2", len(committed))
+	}
+}
+
+func findPicker(o fyne.CanvasObject) ColorPicker {
+	switch o := o.(type) {
+	case ColorPicker:
+		return o
+	case *fyne.Container:
+		for _, child := range o.Objects {
+			if p := findPicker(child); p != nil {
+				return p
+			}
+		}
+	}
+	return nil
+}
@@ -3,7 +3,9 @@ package colorpicker
 import (
 	"image"
 	"image/color"
-	"image/draw"
+	"runtime"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -12,41 +14,141 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+const (
+	// keyStep is the amount moved by an arrow key, relative to the range of the value.
+	keyStep = 0.01
+	// keyShiftStep is the amount moved by an arrow key while Shift is held down.
+	keyShiftStep = 0.1
+
+	// minParallelPixels is the number of pixels below which a raster is generated on the calling goroutine.
+	minParallelPixels = 64 * 64
+
+	// scrollEndDelay is the time without scrolling after which a scroll gesture ends.
+	scrollEndDelay = 300 * time.Millisecond
+)
+
+// pixelColorFunc returns the color of the pixel at (x, y) in the raster of w x h pixels.
+// It is called concurrently from several goroutines, so it must not modify any state.
+type pixelColorFunc func(x, y, w, h int) color.NRGBA
+
 type tappableRaster struct {
-	widget.BaseWidget
+	widget.DisableableWidget
 
-	r   *canvas.Raster
-	img draw.Image
+	r          *canvas.Raster
+	img        *image.NRGBA
+	pixelColor pixelColorFunc
+	// dirty is true if pixelColor has been replaced since img was generated,
+	// and generatedDisabled is whether img was generated while disabled.
+	dirty             bool
+	generatedDisabled bool
 
 	tapped func(fyne.Position)
+	// moved is called with the amount to move on each axis by the arrow keys,
+	// and with (-1, -1) or (1, 1) to jump to the extremes by the Home and End keys.
+	moved func(dx, dy float64)
+	// started and ended are called around each tap, drag, key press or scroll gesture that calls tapped or moved.
+	started, ended func()
+	// scrollEnd ends the scroll gesture in progress, or is nil if not scrolling.
+	scrollEnd *time.Timer
+	// shortcut is called with the shortcuts typed while the raster has focus, and reports whether it has handled them,
+	// and keyTyped is called with the keys typed that do not move the marker.
+	shortcut func(fyne.Shortcut) bool
+	keyTyped func(*fyne.KeyEvent)
+
+	focused  bool
+	shift    bool
+	dragging bool
 }
 
-func newTappableRaster(pixelColor func(x, y, w, h int) color.Color) *tappableRaster {
-	r := &tappableRaster{
-		r: &canvas.Raster{},
-	}
+func newTappableRaster(pixelColor pixelColorFunc) *tappableRaster {
+	r := &tappableRaster{}
+	r.r = &canvas.Raster{Generator: r.generate}
 	r.setPixelColor(pixelColor)
 	r.ExtendBaseWidget(r)
 	return r
 }
 
-func (r *tappableRaster) setPixelColor(pixelColor func(x, y, w, h int) color.Color) {
-	r.r.Generator = func(w, h int) image.Image {
-		if r.img == nil || r.img.Bounds().Size().X != w || r.img.Bounds().Size().Y != h {
-			rect := image.Rect(0, 0, w, h)
-			r.img = image.NewNRGBA(rect)
+// setPixelColor replaces the colors of the pixels, which are generated on the next refresh.
+func (r *tappableRaster) setPixelColor(pixelColor pixelColorFunc) {
+	r.pixelColor = pixelColor
+	r.dirty = true
+}
+
+// generate returns the image of w x h pixels, which is reused as is
+// unless the size or the colors of the pixels have changed.
+func (r *tappableRaster) generate(w, h int) image.Image {
+	if r.img == nil || r.img.Rect.Dx() != w || r.img.Rect.Dy() != h {
+		r.img = image.NewNRGBA(image.Rect(0, 0, w, h))
+		r.dirty = true
+	}
+	if r.dirty || r.generatedDisabled != r.Disabled() {
+		r.generatedDisabled = r.Disabled()
+		pixelColor := r.pixelColor
+		if r.generatedDisabled {
+			pixelColor = disabledPixelColor(pixelColor)
 		}
+		fillPixels(r.img, pixelColor)
+		r.dirty = false
+	}
+	return r.img
+}
+
+// disabledPixelColor returns the colors of pixelColor desaturated and dimmed, which are displayed while disabled.
+func disabledPixelColor(pixelColor pixelColorFunc) pixelColorFunc {
+	return func(x, y, w, h int) color.NRGBA {
+		return disabledColor(pixelColor(x, y, w, h))
+	}
+}
+
+// disabledColor returns c desaturated and dimmed, which is displayed while disabled.
+func disabledColor(c color.Color) color.NRGBA {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	l := (299*int(n.R) + 587*int(n.G) + 114*int(n.B)) / 1000
+	v := uint8(0x40 + l/2)
+	return color.NRGBA{v, v, v, n.A}
+}
+
+// fillPixels sets every pixel of img to pixelColor, splitting the rows across goroutines.
+func fillPixels(img *image.NRGBA, pixelColor pixelColorFunc) {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	workers := runtime.GOMAXPROCS(0)
+	if w*h < minParallelPixels || workers == 1 {
+		fillRows(img, pixelColor, 0, h)
+		return
+	}
+	rows := (h + workers - 1) / workers
+	var wg sync.WaitGroup
+	for y := 0; y < h; y += rows {
+		wg.Add(1)
+		go func(y0, y1 int) {
+			defer wg.Done()
+			fillRows(img, pixelColor, y0, y1)
+		}(y, min(y+rows, h))
+	}
+	wg.Wait()
+}
+
+// fillRows sets the pixels of the rows in [y0, y1) of img to pixelColor.
+func fillRows(img *image.NRGBA, pixelColor pixelColorFunc, y0, y1 int) {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	for y := y0; y < y1; y++ {
+		pix := img.Pix[y*img.Stride : y*img.Stride+w*4]
 		for x := 0; x < w; x++ {
-			for y := 0; y < h; y++ {
-				r.img.Set(x, y, pixelColor(x, y, w, h))
-			}
+			c := pixelColor(x, y, w, h)
+			p := pix[x*4 : x*4+4 : x*4+4]
+			p[0], p[1], p[2], p[3] = c.R, c.G, c.B, c.A
 		}
-		return r.img
 	}
 }
 
 func (r *tappableRaster) CreateRenderer() fyne.WidgetRenderer {
-	return &rasterWidgetRender{raster: r}
+	focus := &canvas.Rectangle{
+		FillColor:   transparent,
+		StrokeColor: theme.FocusColor(),
+		StrokeWidth: 2,
+	}
+	focus.Hide()
+	return &rasterWidgetRender{raster: r, focus: focus}
 }
 
 func (r *tappableRaster) SetMinSize(size fyne.Size) {
@@ -58,22 +160,137 @@ func (r *tappableRaster) MinSize() fyne.Size {
 }
 
 func (r *tappableRaster) Tapped(e *fyne.PointEvent) {
-	if r.tapped != nil && r.isOnRaster(e.Position) {
+	if r.tapped != nil && !r.Disabled() && r.isOnRaster(e.Position) {
+		r.start()
 		r.tapped(e.Position)
+		r.end()
 	}
 }
 
 func (r *tappableRaster) TappedSecondary(*fyne.PointEvent) {}
 
 func (r *tappableRaster) Dragged(e *fyne.DragEvent) {
-	if r.tapped != nil && r.isOnRaster(e.Position) {
+	if r.tapped != nil && !r.Disabled() && r.isOnRaster(e.Position) {
+		if !r.dragging {
+			r.dragging = true
+			r.start()
+		}
 		r.tapped(e.Position)
 	}
 }
 
-func (r *tappableRaster) DragEnd() {}
+func (r *tappableRaster) DragEnd() {
+	if r.dragging {
+		r.dragging = false
+		r.end()
+	}
+}
+
+func (r *tappableRaster) start() {
+	if r.started != nil {
+		r.started()
+	}
+}
+
+func (r *tappableRaster) end() {
+	if r.ended != nil {
+		r.ended()
+	}
+}
+
+// endScroll ends the scroll gesture in progress, if any.
+func (r *tappableRaster) endScroll() {
+	if r.scrollEnd == nil {
+		return
+	}
+	r.scrollEnd.Stop()
+	r.scrollEnd = nil
+	r.end()
+}
+
+// move calls moved as a single change.
+func (r *tappableRaster) move(dx, dy float64) {
+	r.start()
+	r.moved(dx, dy)
+	r.end()
+}
+
+func (r *tappableRaster) FocusGained() {
+	r.focused = true
+	r.Refresh()
+}
+
+func (r *tappableRaster) FocusLost() {
+	r.focused = false
+	r.shift = false
+	r.Refresh()
+}
+
+func (r *tappableRaster) TypedRune(rune) {}
+
+func (r *tappableRaster) TypedKey(e *fyne.KeyEvent) {
+	if r.Disabled() {
+		return
+	}
+	if r.moved == nil {
+		r.typeOtherKey(e)
+		return
+	}
+	step := keyStep
+	if r.shift {
+		step = keyShiftStep
+	}
+	switch e.Name {
+	case fyne.KeyLeft:
+		r.move(-step, 0)
+	case fyne.KeyRight:
+		r.move(step, 0)
+	case fyne.KeyUp:
+		r.move(0, -step)
+	case fyne.KeyDown:
+		r.move(0, step)
+	case fyne.KeyHome:
+		r.move(-1, -1)
+	case fyne.KeyEnd:
+		r.move(1, 1)
+	default:
+		r.typeOtherKey(e)
+	}
+}
+
+func (r *tappableRaster) typeOtherKey(e *fyne.KeyEvent) {
+	if r.keyTyped != nil {
+		r.keyTyped(e)
+	}
+}
+
+// TypedShortcut handles s by shortcut, or passes it on to the canvas otherwise,
+// as the driver sends the shortcuts only to the focused object if it is fyne.Shortcutable.
+func (r *tappableRaster) TypedShortcut(s fyne.Shortcut) {
+	if r.shortcut != nil && !r.Disabled() && r.shortcut(s) {
+		return
+	}
+	if c, ok := fyne.CurrentApp().Driver().CanvasForObject(r).(interface{ TypedShortcut(fyne.Shortcut) }); ok {
+		c.TypedShortcut(s)
+	}
+}
+
+func (r *tappableRaster) KeyDown(e *fyne.KeyEvent) {
+	if e.Name == desktop.KeyShiftLeft || e.Name == desktop.KeyShiftRight {
+		r.shift = true
+	}
+}
+
+func (r *tappableRaster) KeyUp(e *fyne.KeyEvent) {
+	if e.Name == desktop.KeyShiftLeft || e.Name == desktop.KeyShiftRight {
+		r.shift = false
+	}
+}
 
 func (r *tappableRaster) Cursor() desktop.Cursor {
+	if r.Disabled() {
+		return desktop.DefaultCursor
+	}
 	return desktop.CrosshairCursor
 }
 
@@ -81,12 +298,58 @@ func (r *tappableRaster) isOnRaster(p fyne.Position) bool {
 	return 0 <= p.X && p.X <= r.Size().Width && 0 <= p.Y && p.Y <= r.Size().Height
 }
 
+// scrollNotchDelta is the scroll delta of one notch of a mouse wheel on most platforms.
+const scrollNotchDelta = 25
+
+// scrollableRaster is a tappableRaster for bars, which also moves by scrolling.
+// The areas do not implement fyne.Scrollable so that they do not block scrolling of the parent.
+type scrollableRaster struct {
+	*tappableRaster
+
+	// step returns the amount moved by one notch, relative to the range of the value.
+	step func() float64
+}
+
+func newScrollableRaster(pixelColor pixelColorFunc, step func() float64) *scrollableRaster {
+	r := &scrollableRaster{
+		tappableRaster: &tappableRaster{},
+		step:           step,
+	}
+	r.r = &canvas.Raster{Generator: r.generate}
+	r.setPixelColor(pixelColor)
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// Scrolled moves the marker. The scroll events sent until scrolling stops for scrollEndDelay are a single change,
+// so that a gesture on a trackpad is not committed many times.
+func (r *scrollableRaster) Scrolled(e *fyne.ScrollEvent) {
+	if r.moved == nil || r.Disabled() {
+		return
+	}
+	if r.scrollEnd == nil {
+		r.start()
+	}
+	step := r.step() / scrollNotchDelta
+	// scrolling up moves the marker up, as the up key does
+	r.moved(float64(e.Scrolled.DX)*step, -float64(e.Scrolled.DY)*step)
+	if r.scrollEnd == nil {
+		r.scrollEnd = time.AfterFunc(scrollEndDelay, func() {
+			fyne.Do(r.endScroll)
+		})
+	} else {
+		r.scrollEnd.Reset(scrollEndDelay)
+	}
+}
+
 type rasterWidgetRender struct {
 	raster *tappableRaster
+	focus  *canvas.Rectangle
 }
 
 func (r *rasterWidgetRender) Layout(size fyne.Size) {
 	r.raster.r.Resize(size)
+	r.focus.Resize(size)
 }
 
 func (r *rasterWidgetRender) MinSize() fyne.Size {
@@ -94,6 +357,9 @@ func (r *rasterWidgetRender) MinSize() fyne.Size {
 }
 
 func (r *rasterWidgetRender) Refresh() {
+	r.focus.StrokeColor = theme.FocusColor()
+	r.focus.Hidden = !r.raster.focused
+	canvas.Refresh(r.focus)
 	canvas.Refresh(r.raster.r)
 }
 
@@ -102,7 +368,7 @@ func (r *rasterWidgetRender) BackgroundColor() color.Color {
 }
 
 func (r *rasterWidgetRender) Objects() []fyne.CanvasObject {
-	return []fyne.CanvasObject{r.raster.r}
+	return []fyne.CanvasObject{r.raster.r, r.focus}
 }
 
 func (r *rasterWidgetRender) Destroy() {}
@@ -0,0 +1,182 @@
+package colorpicker
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/software"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestFillPixels(t *testing.T) {
+	pixelColor := func(x, y, w, h int) color.NRGBA {
+		return color.NRGBA{R: uint8(x), G: uint8(y), B: uint8(w), A: uint8(h)}
+	}
+	for _, size := range []image.Point{{1, 1}, {300, 3}, {3, 300}, {100, 100}, {257, 131}} {
+		img := image.NewNRGBA(image.Rect(0, 0, size.X, size.Y))
+		fillPixels(img, pixelColor)
+		for y := 0; y < size.Y; y++ {
+			for x := 0; x < size.X; x++ {
+				if got, want := img.NRGBAAt(x, y), pixelColor(x, y, size.X, size.Y); got != want {
+					t.Fatalf("%v: NRGBAAt(%d, %d) = %v; want %v", size, x, y, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestTappableRasterCache(t *testing.T) {
+	calls := 0
+	r := newTappableRaster(func(int, int, int, int) color.NRGBA {
+		calls++
+		return transparent
+	})
+
+	r.generate(10, 10)
+	r.generate(10, 10)
+	if calls != 100 {
+		t.Errorf("pixelColor called %d times for unchanged raster; want 100", calls)
+	}
+	r.generate(10, 20)
+	if calls != 300 {
+		t.Errorf("pixelColor called %d times after resize; want 300", calls)
+	}
+	r.setPixelColor(func(int, int, int, int) color.NRGBA { return color.NRGBA{A: 0xff} })
+	img := r.generate(10, 20).(*image.NRGBA)
+	if c := img.NRGBAAt(5, 5); c != (color.NRGBA{A: 0xff}) {
+		t.Errorf("NRGBAAt(5, 5) = %v after setPixelColor; want opaque black", c)
+	}
+}
+
+func TestPickerAreaRegeneration(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range []struct {
+		style PickerStyle
+		// keep changes the color without changing the channel the area is created from.
+		keep func(h, s, v, a float64) (float64, float64, float64, float64)
+	}{
+		{StyleHue, func(h, s, v, a float64) (float64, float64, float64, float64) { return h, s / 2, v / 2, a }},
+		{StyleHueCircle, func(h, s, v, a float64) (float64, float64, float64, float64) { return h, s / 2, v / 2, a }},
+		{StyleValue, func(h, s, v, a float64) (float64, float64, float64, float64) { return h / 2, s / 2, v, a }},
+		{StyleSaturation, func(h, s, v, a float64) (float64, float64, float64, float64) { return h / 2, s, v / 2, a }},
+		{StyleLightness, func(h, s, v, a float64) (float64, float64, float64, float64) { return h, s / 2, v / 2, a }},
+		{StyleOKLCH, func(h, s, v, a float64) (float64, float64, float64, float64) { return h, s, v, a / 2 }},
+	} {
+		p := New(200, style.style)
+		p.SetHSVA(0.6, 0.7, 0.8, 1)
+		area := pickerBase(p).colorPickerRaster
+		area.generate(10, 10)
+
+		p.SetHSVA(style.keep(p.HSVA()))
+		if area.dirty {
+			t.Errorf("style %d: area regenerated after SetHSVA with the same area", style.style)
+		}
+		p.SetHSVA(0.2, 0.3, 0.4, 1)
+		if !area.dirty {
+			t.Errorf("style %d: area not regenerated after SetHSVA with another area", style.style)
+		}
+	}
+}
+
+func pickerBase(p ColorPicker) *colorPickerBase {
+	switch p := p.(type) {
+	case *defaultHueColorPicker:
+		return p.colorPickerBase
+	case *circleHueColorPicker:
+		return p.colorPickerBase
+	case *valueColorPicker:
+		return p.colorPickerBase
+	case *saturationColorPicker:
+		return p.colorPickerBase
+	case *lightnessColorPicker:
+		return p.colorPickerBase
+	case *oklchColorPicker:
+		return p.colorPickerBase
+	}
+	panic(fmt.Sprintf("unknown picker %T", p))
+}
+
+func TestPickerRendersAtScale(t *testing.T) {
+	test.NewTempApp(t)
+	test.ApplyTheme(t, theme.DarkTheme())
+	horizontal := WithOrientation(OrientationHorizontal)
+	for _, style := range []struct {
+		name  string
+		style PickerStyle
+		opts  []Option
+	}{
+		{"hue", StyleHue, nil},
+		{"hue_circle", StyleHueCircle, nil},
+		{"value", StyleValue, nil},
+		{"hue_horizontal", StyleHue, []Option{horizontal}},
+		{"hue_circle_horizontal", StyleHueCircle, []Option{horizontal}},
+	} {
+		for _, scale := range []float32{1, 1.5, 2} {
+			p := NewWithOptions(style.style, append([]Option{WithSize(100)}, style.opts...)...)
+			p.SetHSVA(0.6, 0.7, 0.8, 0.5)
+
+			c := software.NewCanvas()
+			c.SetPadded(false)
+			c.SetScale(scale)
+			c.SetContent(p)
+			c.Resize(p.MinSize())
+			test.AssertRendersToImage(t, fmt.Sprintf("picker_%s_%gx.png", style.name, scale), c)
+		}
+	}
+}
+
+// BenchmarkPicker measures updating the color of each style and generating all of its rasters.
+func BenchmarkPicker(b *testing.B) {
+	test.NewTempApp(b)
+	styles := []struct {
+		name  string
+		style PickerStyle
+	}{
+		{"Hue", StyleHue},
+		{"HueCircle", StyleHueCircle},
+		{"Value", StyleValue},
+		{"Saturation", StyleSaturation},
+		{"Lightness", StyleLightness},
+		{"OKLCH", StyleOKLCH},
+	}
+	for _, s := range styles {
+		for _, size := range []float32{200, 1000} {
+			b.Run(fmt.Sprintf("%s/%.0f", s.name, size), func(b *testing.B) {
+				p := New(size, s.style)
+				rasters := collectRasters(p)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					p.SetHSVA(float64(i%100)/100, 0.5, 0.5, 1)
+					for _, r := range rasters {
+						r.Generator(int(r.Size().Width), int(r.Size().Height))
+					}
+				}
+			})
+		}
+	}
+}
+
+func collectRasters(o fyne.CanvasObject) []*canvas.Raster {
+	switch o := o.(type) {
+	case *canvas.Raster:
+		return []*canvas.Raster{o}
+	case *fyne.Container:
+		var rasters []*canvas.Raster
+		for _, child := range o.Objects {
+			rasters = append(rasters, collectRasters(child)...)
+		}
+		return rasters
+	case fyne.Widget:
+		var rasters []*canvas.Raster
+		for _, child := range test.WidgetRenderer(o).Objects() {
+			rasters = append(rasters, collectRasters(child)...)
+		}
+		return rasters
+	}
+	return nil
+}
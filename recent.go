@@ -0,0 +1,185 @@
+package colorpicker
+
+import (
+	"image/color"
+	"slices"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/lusingander/colorpicker/csscolor"
+)
+
+// defaultRecentColorsLimit is the number of colors kept if the limit is not positive.
+const defaultRecentColorsLimit = 10
+
+// ColorSetter is implemented by ColorPicker and PickerOpenWidget.
+type ColorSetter interface {
+	SetColor(color.Color)
+}
+
+// savedRecentColors are the rows following the colors saved under each key of each preferences,
+// which are notified by a single change listener per key, since listeners cannot be removed from the preferences.
+var savedRecentColors = struct {
+	sync.Mutex
+	rows map[savedRecentColorsKey][]*RecentColors
+}{rows: map[savedRecentColorsKey][]*RecentColors{}}
+
+type savedRecentColorsKey struct {
+	prefs fyne.Preferences
+	key   string
+}
+
+// RecentColors is a row of swatches of the colors committed recently, from the newest.
+// Tapping a swatch sets its color on the attached pickers and picker-open widgets.
+type RecentColors struct {
+	widget.BaseWidget
+
+	// key is the preference key the colors are saved under, or empty if they are not saved.
+	key string
+	// prefs are the preferences the colors saved by other rows are followed in, or nil after Close.
+	prefs   fyne.Preferences
+	limit   int
+	colors  []color.Color
+	targets []ColorSetter
+	row     *fyne.Container
+}
+
+// NewRecentColors returns a row of up to limit recent colors, or 10 if limit is not positive.
+// Unless key is empty, the colors are saved in the preferences of the current app under key,
+// so that rows with the same key share their colors and rows with different keys keep them apart.
+// Such a row stays reachable from the preferences until it is closed.
+func NewRecentColors(key string, limit int) *RecentColors {
+	if limit <= 0 {
+		limit = defaultRecentColorsLimit
+	}
+	r := &RecentColors{
+		key:   key,
+		limit: limit,
+		row:   container.NewHBox(),
+	}
+	r.ExtendBaseWidget(r)
+	if key != "" {
+		prefs := fyne.CurrentApp().Preferences()
+		r.load(prefs.StringList(key))
+		r.watch(prefs)
+	}
+	return r
+}
+
+// Close stops following the colors saved by other rows with the same key, so that the row can be discarded.
+func (r *RecentColors) Close() {
+	if r.prefs == nil {
+		return
+	}
+	savedRecentColors.Lock()
+	defer savedRecentColors.Unlock()
+	k := savedRecentColorsKey{r.prefs, r.key}
+	savedRecentColors.rows[k] = slices.DeleteFunc(savedRecentColors.rows[k], func(row *RecentColors) bool {
+		return row == r
+	})
+	r.prefs = nil
+}
+
+// watch makes r load the colors saved under its key in prefs whenever they change.
+func (r *RecentColors) watch(prefs fyne.Preferences) {
+	savedRecentColors.Lock()
+	defer savedRecentColors.Unlock()
+	k := savedRecentColorsKey{prefs, r.key}
+	rows, ok := savedRecentColors.rows[k]
+	if !ok {
+		prefs.AddChangeListener(func() {
+			savedRecentColors.Lock()
+			rows := slices.Clone(savedRecentColors.rows[k])
+			savedRecentColors.Unlock()
+			for _, row := range rows {
+				row.load(prefs.StringList(k.key))
+			}
+		})
+	}
+	savedRecentColors.rows[k] = append(rows, r)
+	r.prefs = prefs
+}
+
+// Attach makes taps on the swatches set the color on target, and adds the colors committed on target.
+// Committed colors are only added if target was created by this package.
+func (r *RecentColors) Attach(target ColorSetter) {
+	r.targets = append(r.targets, target)
+	if l, ok := target.(commitListenable); ok {
+		l.addCommitListener(func(_, to color.Color) {
+			r.Add(to)
+		})
+	}
+}
+
+// Colors returns the recent colors from the newest.
+func (r *RecentColors) Colors() []color.Color {
+	return slices.Clone(r.colors)
+}
+
+// Add adds c as the newest color, removing the same color from older ones and the oldest beyond the limit.
+func (r *RecentColors) Add(c color.Color) {
+	colors := []color.Color{c}
+	for _, old := range r.colors {
+		if len(colors) < r.limit && !colorEquals(old, c) {
+			colors = append(colors, old)
+		}
+	}
+	r.setColors(colors)
+	if r.key != "" {
+		r.save()
+	}
+}
+
+func (r *RecentColors) load(values []string) {
+	if slices.Equal(values, r.values()) {
+		return
+	}
+	var colors []color.Color
+	for _, s := range values {
+		if c, err := csscolor.Parse(s); err == nil && len(colors) < r.limit {
+			colors = append(colors, c)
+		}
+	}
+	r.setColors(colors)
+}
+
+func (r *RecentColors) save() {
+	fyne.CurrentApp().Preferences().SetStringList(r.key, r.values())
+}
+
+// values returns the colors as strings saved in the preferences.
+func (r *RecentColors) values() []string {
+	values := make([]string, len(r.colors))
+	for i, c := range r.colors {
+		values[i] = csscolor.Format(c, csscolor.NotationHex)
+	}
+	return values
+}
+
+func (r *RecentColors) setColors(colors []color.Color) {
+	r.colors = colors
+	r.row.RemoveAll()
+	for _, c := range colors {
+		r.row.Add(newSwatchButton(c, func() {
+			r.apply(c)
+		}))
+	}
+	r.Refresh()
+}
+
+func (r *RecentColors) apply(c color.Color) {
+	for _, t := range r.targets {
+		t.SetColor(c)
+	}
+}
+
+func (r *RecentColors) MinSize() fyne.Size {
+	// keep the height of the row while it is empty
+	return r.BaseWidget.MinSize().Max(swatchMinSize)
+}
+
+func (r *RecentColors) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(r.row)
+}
@@ -0,0 +1,96 @@
+package colorpicker
+
+import (
+	"image/color"
+	"slices"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestRecentColors(t *testing.T) {
+	test.NewTempApp(t)
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	green := color.NRGBA{G: 0xff, A: 0xff}
+	blue := color.NRGBA{B: 0xff, A: 0x80}
+	r := NewRecentColors("", 2)
+	r.Add(red)
+	r.Add(green)
+	r.Add(red)
+	r.Add(blue)
+	if got, want := r.Colors(), []color.Color{blue, red}; !slices.Equal(got, want) {
+		t.Errorf("Colors() = %v; want %v", got, want)
+	}
+	if got := len(r.row.Objects); got != 2 {
+		t.Errorf("%d swatches; want 2", got)
+	}
+}
+
+func TestRecentColorsAttach(t *testing.T) {
+	test.NewTempApp(t)
+	p := New(200, StyleHue).(*defaultHueColorPicker)
+	p.SetHSVA(0, 1, 1, 1)
+	r := NewRecentColors("", 0)
+	r.Attach(p)
+
+	test.TapAt(p.hueBar.raster, fyne.NewPos(5, 50))
+	picked := p.Color()
+	p.SetColor(color.Black)
+	if got := r.Colors(); len(got) != 1 || !colorEquals(got[0], picked) {
+		t.Fatalf("Colors() = %v; want only the committed color %v", got, picked)
+	}
+
+	test.Tap(r.row.Objects[0].(*swatchButton))
+	if got := p.Color(); !colorEquals(got, picked) {
+		t.Errorf("Color() = %v after tapping the swatch; want %v", got, picked)
+	}
+}
+
+func TestRecentColorsPreferences(t *testing.T) {
+	a := test.NewTempApp(t)
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	blue := color.NRGBA{B: 0xff, A: 0x80}
+	r1 := NewRecentColors("recent", 0)
+	r2 := NewRecentColors("recent", 0)
+	other := NewRecentColors("other", 0)
+	r1.Add(red)
+	r1.Add(blue)
+
+	if got, want := a.Preferences().StringList("recent"), []string{"#0000ff80", "#ff0000"}; !slices.Equal(got, want) {
+		t.Errorf("preferences = %v; want %v", got, want)
+	}
+	if got, want := r2.Colors(), []color.Color{blue, red}; !slices.Equal(got, want) {
+		t.Errorf("Colors() of the same key = %v; want %v", got, want)
+	}
+	if got := other.Colors(); len(got) != 0 {
+		t.Errorf("Colors() of another key = %v; want none", got)
+	}
+	if got := NewRecentColors("recent", 1).Colors(); !slices.Equal(got, []color.Color{blue}) {
+		t.Errorf("Colors() loaded with limit 1 = %v; want %v", got, []color.Color{blue})
+	}
+}
+
+func TestRecentColorsClose(t *testing.T) {
+	a := test.NewTempApp(t)
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	listeners := len(a.Preferences().ChangeListeners())
+	r1 := NewRecentColors("recent", 0)
+	r2 := NewRecentColors("recent", 0)
+	NewRecentColors("other", 0).Close()
+	if got, want := len(a.Preferences().ChangeListeners()), listeners+2; got != want {
+		t.Errorf("%d change listeners for 2 keys; want %d", got, want)
+	}
+
+	r2.Close()
+	r1.Add(red)
+	if got := r2.Colors(); len(got) != 0 {
+		t.Errorf("Colors() of a closed row = %v; want none", got)
+	}
+	if got := NewRecentColors("recent", 0).Colors(); !slices.Equal(got, []color.Color{red}) {
+		t.Errorf("Colors() of a new row = %v; want %v", got, []color.Color{red})
+	}
+	if got, want := len(a.Preferences().ChangeListeners()), listeners+2; got != want {
+		t.Errorf("%d change listeners after adding a row for a known key; want %d", got, want)
+	}
+}
@@ -5,7 +5,7 @@ import (
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
@@ -18,62 +18,150 @@ const (
 // PickerOpenWidget represents a widget that can open a color picker.
 type PickerOpenWidget interface {
 	fyne.CanvasObject
+	fyne.Disableable
 
 	SetColor(color.Color)
 	SetOnChange(f func(color.Color))
 	SetPickerStyle(s PickerStyle)
 }
 
-type colorSelectModalRect struct {
-	*tappableRect
+// BoundPickerOpenWidget represents a PickerOpenWidget whose color is bound to data.
+type BoundPickerOpenWidget interface {
+	PickerOpenWidget
+
+	// Unbind stops following data and setting the color to it, so that the widget can be discarded.
+	Unbind()
+}
+
+// pickerOpener opens a picker for a PickerOpenWidget, applying the colors selected while it is open.
+type pickerOpener struct {
 	parent      fyne.Window
 	onChange    func(color.Color)
 	pickerStyle PickerStyle
+
+	// commitListeners are called with the colors before and after the dialog when it is confirmed,
+	// or the pop-up when it is closed.
+	commitListeners []func(from, to color.Color)
+}
+
+func (o *pickerOpener) SetOnChange(f func(color.Color)) {
+	o.onChange = f
+}
+
+func (o *pickerOpener) SetPickerStyle(s PickerStyle) {
+	o.pickerStyle = s
+}
+
+// addCommitListener registers f to be called when the dialog is confirmed or the pop-up is closed after the color has been changed.
+func (o *pickerOpener) addCommitListener(f func(from, to color.Color)) {
+	o.commitListeners = append(o.commitListeners, f)
+}
+
+// commit calls the commit listeners if the color has been changed from from to to.
+func (o *pickerOpener) commit(from, to color.Color) {
+	if colorEquals(from, to) {
+		return
+	}
+	for _, f := range o.commitListeners {
+		f(from, to)
+	}
+}
+
+// change calls the OnChange callback and then apply with c.
+func (o *pickerOpener) change(c color.Color, apply func(color.Color)) {
+	if o.onChange != nil {
+		o.onChange(c)
+	}
+	apply(c)
+}
+
+// showDialog opens a dialog to select a color starting from from, which is applied by apply while it is selected,
+// and reverted when the dialog is canceled.
+func (o *pickerOpener) showDialog(from color.Color, apply func(color.Color)) {
+	d := NewColorDialog("Select color", from, func(to color.Color) {
+		o.commit(from, to)
+	}, o.parent)
+	d.SetPickerStyle(o.pickerStyle)
+	d.SetPickerOptions(WithSize(colorSelectModalPickerDefaultSize))
+	d.SetOnPreview(func(c color.Color) {
+		o.change(c, apply)
+	})
+	d.Show()
+}
+
+type colorSelectModalRect struct {
+	*tappableRect
+	*pickerOpener
+	data   ColorBinding
+	unbind func()
 }
 
 // NewColorSelectModalRect returns a rectangle that can be tapped to open a color picker modal.
 func NewColorSelectModalRect(parent fyne.Window, minSize fyne.Size, defalutColor color.Color) PickerOpenWidget {
 	rect := &colorSelectModalRect{
 		tappableRect: newTappableRect(defalutColor),
-		parent:       parent,
-		pickerStyle:  StyleHue,
+		pickerOpener: &pickerOpener{parent: parent, pickerStyle: StyleHue},
 	}
 	rect.tappableRect.tapped = rect.tapped
 	rect.tappableRect.SetMinSize(minSize)
 	return rect
 }
 
-func (r *colorSelectModalRect) SetOnChange(f func(color.Color)) {
-	r.onChange = f
+// NewColorSelectModalRectWithData returns a rectangle that can be tapped to open a color picker modal,
+// whose color is bound to data. If data holds no color, it is set to the color of the rectangle.
+// The rectangle stays reachable from data until it is unbound.
+func NewColorSelectModalRectWithData(parent fyne.Window, minSize fyne.Size, data ColorBinding) BoundPickerOpenWidget {
+	rect := NewColorSelectModalRect(parent, minSize, transparent).(*colorSelectModalRect)
+	rect.data = data
+	listener := binding.NewDataListener(func() {
+		c, err := data.Get()
+		if err != nil || colorEquals(c, rect.color()) {
+			return
+		}
+		if c == nil {
+			data.Set(rect.color())
+			return
+		}
+		rect.setColor(c)
+	})
+	data.AddListener(listener)
+	rect.unbind = func() {
+		data.RemoveListener(listener)
+	}
+	return rect
 }
 
-func (r *colorSelectModalRect) SetPickerStyle(s PickerStyle) {
-	r.pickerStyle = s
+// Unbind stops following the data bound by NewColorSelectModalRectWithData and setting the color to it.
+func (r *colorSelectModalRect) Unbind() {
+	if r.unbind == nil {
+		return
+	}
+	r.unbind()
+	r.unbind = nil
+	r.data = nil
 }
 
 func (r *colorSelectModalRect) SetColor(c color.Color) {
+	if r.data != nil {
+		r.data.Set(c)
+	}
 	r.setColor(c)
 }
 
-func (r *colorSelectModalRect) tapped(e *fyne.PointEvent) {
-	picker := New(colorSelectModalPickerDefaultSize, r.pickerStyle)
-	picker.SetColor(r.color())
-	picker.SetOnChanged(func(c color.Color) {
-		if r.onChange != nil {
-			r.onChange(c)
-		}
-		r.setColor(c)
-	})
-
-	dialog.ShowCustom("Select color", "OK", fyne.NewContainer(picker), r.parent)
+func (r *colorSelectModalRect) tapped(*fyne.PointEvent) {
+	r.showDialog(r.color(), r.SetColor)
 }
 
 func (r *colorSelectModalRect) Cursor() desktop.Cursor {
+	if r.Disabled() {
+		return desktop.DefaultCursor
+	}
 	return desktop.PointerCursor
 }
 
+// tappableRect is a bordered rectangle that calls tapped when it is tapped, unless it is disabled.
 type tappableRect struct {
-	widget.BaseWidget
+	widget.DisableableWidget
 	rect   *canvas.Rectangle
 	tapped func(*fyne.PointEvent)
 }
@@ -100,7 +188,7 @@ func (r *tappableRect) setColor(c color.Color) {
 }
 
 func (r *tappableRect) CreateRenderer() fyne.WidgetRenderer {
-	return &tappableRectRenderer{rect: r.rect}
+	return &tappableRectRenderer{tappable: r, rect: r.rect, dim: &canvas.Rectangle{}}
 }
 
 func (r *tappableRect) SetMinSize(size fyne.Size) {
@@ -112,7 +200,7 @@ func (r *tappableRect) MinSize() fyne.Size {
 }
 
 func (r *tappableRect) Tapped(e *fyne.PointEvent) {
-	if r.tapped != nil {
+	if r.tapped != nil && !r.Disabled() {
 		r.tapped(e)
 	}
 }
@@ -120,11 +208,15 @@ func (r *tappableRect) Tapped(e *fyne.PointEvent) {
 func (r *tappableRect) TappedSecondary(*fyne.PointEvent) {}
 
 type tappableRectRenderer struct {
-	rect *canvas.Rectangle
+	tappable *tappableRect
+	rect     *canvas.Rectangle
+	// dim covers the rectangle while it is disabled.
+	dim *canvas.Rectangle
 }
 
 func (r *tappableRectRenderer) Layout(size fyne.Size) {
 	r.rect.Resize(size)
+	r.dim.Resize(size)
 }
 
 func (r *tappableRectRenderer) MinSize() fyne.Size {
@@ -132,7 +224,12 @@ func (r *tappableRectRenderer) MinSize() fyne.Size {
 }
 
 func (r *tappableRectRenderer) Refresh() {
+	r.dim.FillColor = transparent
+	if r.tappable.Disabled() {
+		r.dim.FillColor = disabledOverlayColor()
+	}
 	canvas.Refresh(r.rect)
+	canvas.Refresh(r.dim)
 }
 
 func (r *tappableRectRenderer) BackgroundColor() color.Color {
@@ -140,7 +237,7 @@ func (r *tappableRectRenderer) BackgroundColor() color.Color {
 }
 
 func (r *tappableRectRenderer) Objects() []fyne.CanvasObject {
-	return []fyne.CanvasObject{r.rect}
+	return []fyne.CanvasObject{r.rect, r.dim}
 }
 
 func (r *tappableRectRenderer) Destroy() {}
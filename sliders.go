@@ -0,0 +1,409 @@
+package colorpicker
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Channel represents a channel of the color that can be adjusted by ChannelSliders.
+type Channel int
+
+const (
+	// ChannelRed is red of RGB, in the range [0, 255].
+	ChannelRed Channel = iota
+	// ChannelGreen is green of RGB, in the range [0, 255].
+	ChannelGreen
+	// ChannelBlue is blue of RGB, in the range [0, 255].
+	ChannelBlue
+	// ChannelHue is hue of HSV and HSL, in degrees.
+	ChannelHue
+	// ChannelSaturation is saturation of HSV, in percent.
+	ChannelSaturation
+	// ChannelValue is value of HSV, in percent.
+	ChannelValue
+	// ChannelLightness is lightness of HSL, in percent.
+	ChannelLightness
+	// ChannelAlpha is alpha, in percent.
+	ChannelAlpha
+)
+
+var (
+	allChannels = []Channel{
+		ChannelRed, ChannelGreen, ChannelBlue,
+		ChannelHue, ChannelSaturation, ChannelValue, ChannelLightness,
+		ChannelAlpha,
+	}
+	// opaqueChannels are the channels adjusted by default on opaque pickers, whose alpha is always 1.
+	opaqueChannels = allChannels[:len(allChannels)-1]
+
+	channelSliderMinSize = fyne.NewSize(150, 20)
+)
+
+func (ch Channel) label() string {
+	return [...]string{"R", "G", "B", "H", "S", "V", "L", "A"}[ch]
+}
+
+// max returns the value displayed when the channel is 1.
+func (ch Channel) max() float64 {
+	switch ch {
+	case ChannelRed, ChannelGreen, ChannelBlue:
+		return 255
+	case ChannelHue:
+		return 360
+	default:
+		return 100
+	}
+}
+
+// get returns the channel of the color of p in the range [0, 1].
+func (ch Channel) get(p ColorPicker) float64 {
+	h, s, v, a := p.HSVA()
+	switch ch {
+	case ChannelRed, ChannelGreen, ChannelBlue:
+		r, g, b, _ := toFloatRGBA(p.Color())
+		return [...]float64{r, g, b}[ch-ChannelRed]
+	case ChannelHue:
+		return h
+	case ChannelSaturation:
+		return s
+	case ChannelValue:
+		return v
+	case ChannelLightness:
+		_, l := hsvToHSL(s, v)
+		return l
+	default:
+		return a
+	}
+}
+
+// set sets the channel of the color of p to f in the range [0, 1].
+func (ch Channel) set(p ColorPicker, f float64) {
+	h, s, v, a := p.HSVA()
+	switch ch {
+	case ChannelRed, ChannelGreen, ChannelBlue:
+		c := color.NRGBAModel.Convert(p.Color()).(color.NRGBA)
+		rgb := [...]*uint8{&c.R, &c.G, &c.B}
+		*rgb[ch-ChannelRed] = roundUint8(f * 255)
+		p.SetColor(c)
+	case ChannelHue:
+		p.SetHSVA(f, s, v, a)
+	case ChannelSaturation:
+		p.SetHSVA(h, f, v, a)
+	case ChannelValue:
+		p.SetHSVA(h, s, f, a)
+	case ChannelLightness:
+		hslSaturation, _ := hsvToHSL(s, v)
+		s, v = hslToHSV(hslSaturation, f)
+		p.SetHSVA(h, s, v, a)
+	default:
+		p.SetHSVA(h, s, v, f)
+	}
+}
+
+// createPixelColor returns the gradient of the channel with the other channels of the color of p.
+func (ch Channel) createPixelColor(p ColorPicker) pixelColorFunc {
+	hue, s, v, _ := p.HSVA()
+	r, g, b, _ := toFloatRGBA(p.Color())
+	hslSaturation, _ := hsvToHSL(s, v)
+	return func(x, y, w, h int) color.NRGBA {
+		f := float64(x) / float64(w)
+		switch ch {
+		case ChannelRed:
+			return fromFloatNRGBA(f, g, b, 1)
+		case ChannelGreen:
+			return fromFloatNRGBA(r, f, b, 1)
+		case ChannelBlue:
+			return fromFloatNRGBA(r, g, f, 1)
+		case ChannelHue:
+			return fromHSV(f, s, v)
+		case ChannelSaturation:
+			return fromHSV(hue, f, v)
+		case ChannelValue:
+			return fromHSV(hue, s, f)
+		case ChannelLightness:
+			return fromHSL(hue, hslSaturation, f)
+		default:
+			return fromFloatNRGBA(r, g, b, f)
+		}
+	}
+}
+
+// ChannelSliders is a panel of labelled sliders with numeric entries,
+// which adjusts each channel of the color of a ColorPicker.
+type ChannelSliders struct {
+	widget.BaseWidget
+
+	picker    ColorPicker
+	listening bool
+	sliders   []*channelSlider
+	entries   []*spinEntry
+	content   fyne.CanvasObject
+
+	// editing is the entry being typed in, whose text is not replaced by the color of the picker.
+	editing *spinEntry
+}
+
+// opaqueReportable is implemented by the pickers of this package.
+type opaqueReportable interface {
+	opaque() bool
+}
+
+// changeGroupable is implemented by the pickers of this package.
+type changeGroupable interface {
+	startChange()
+	endChange()
+}
+
+// NewChannelSliders returns a panel of sliders bound to picker for the given channels, or all channels if none are given,
+// except ChannelAlpha if picker was created with WithOpaque.
+// The sliders only follow colors set on picker by other means if picker was created by this package.
+func NewChannelSliders(picker ColorPicker, channels ...Channel) *ChannelSliders {
+	if len(channels) == 0 {
+		channels = allChannels
+		if o, ok := picker.(opaqueReportable); ok && o.opaque() {
+			channels = opaqueChannels
+		}
+	}
+	s := &ChannelSliders{
+		picker: picker,
+	}
+	rows := container.NewVBox()
+	for _, ch := range channels {
+		ch := ch
+		slider := newChannelSlider(ch)
+		slider.changed = func(f float64) {
+			s.setChannel(ch, f)
+		}
+		slider.raster.started = s.startChange
+		slider.raster.ended = s.endChange
+		entry := newSpinEntry(ch.max())
+		entry.changed = func(v float64) {
+			s.editing = entry
+			defer func() { s.editing = nil }()
+			s.startChange()
+			defer s.endChange()
+			s.setChannel(ch, v/ch.max())
+		}
+		s.sliders = append(s.sliders, slider)
+		s.entries = append(s.entries, entry)
+
+		label := widget.NewLabelWithStyle(ch.label(), fyne.TextAlignCenter, fyne.TextStyle{Monospace: true})
+		rows.Add(container.NewBorder(nil, nil, label, entry, slider))
+	}
+	s.content = rows
+	if l, ok := picker.(changeListenable); ok {
+		l.addChangeListener(func(color.Color) {
+			s.update()
+		})
+		s.listening = true
+	}
+	s.ExtendBaseWidget(s)
+	s.update()
+	return s
+}
+
+func (s *ChannelSliders) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(s.content)
+}
+
+// startChange and endChange make each edit a single change of the picker, if it was created by this package.
+func (s *ChannelSliders) startChange() {
+	if g, ok := s.picker.(changeGroupable); ok {
+		g.startChange()
+	}
+}
+
+func (s *ChannelSliders) endChange() {
+	if g, ok := s.picker.(changeGroupable); ok {
+		g.endChange()
+	}
+}
+
+func (s *ChannelSliders) setChannel(ch Channel, f float64) {
+	ch.set(s.picker, clamp01(f))
+	if !s.listening {
+		s.update()
+	}
+}
+
+func (s *ChannelSliders) update() {
+	for i, slider := range s.sliders {
+		slider.update(s.picker)
+		if s.entries[i] != s.editing {
+			s.entries[i].setValue(slider.channel.get(s.picker) * slider.channel.max())
+		}
+	}
+}
+
+type channelSlider struct {
+	widget.BaseWidget
+
+	channel    Channel
+	value      float64
+	raster     *tappableRaster
+	marker     marker
+	background fyne.CanvasObject
+
+	changed func(float64)
+}
+
+func newChannelSlider(ch Channel) *channelSlider {
+	s := &channelSlider{
+		channel: ch,
+		changed: func(float64) {},
+	}
+	s.raster = newTappableRaster(func(int, int, int, int) color.NRGBA { return transparent })
+	s.raster.SetMinSize(channelSliderMinSize)
+	s.raster.tapped = func(p fyne.Position) {
+		s.changed(clamp01(float64(p.X / s.raster.Size().Width)))
+	}
+	s.raster.moved = func(dx, dy float64) {
+		if dx == 0 {
+			dx = -dy
+		}
+		s.changed(clamp01(s.value + dx))
+	}
+	s.marker = newDefaultMarker(channelSliderMinSize.Height / 2)
+	if ch == ChannelAlpha {
+		s.background = newCheckeredBackground(defaultCheckerboardColors[0], defaultCheckerboardColors[1])
+	}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+func (s *channelSlider) update(p ColorPicker) {
+	s.value = s.channel.get(p)
+	s.raster.setPixelColor(s.channel.createPixelColor(p))
+	s.raster.Refresh()
+	s.updateMarker(s.Size())
+}
+
+func (s *channelSlider) updateMarker(size fyne.Size) {
+	s.marker.setPosition(fyne.NewPos(size.Width*float32(s.value), size.Height/2))
+	s.marker.Refresh()
+}
+
+func (s *channelSlider) CreateRenderer() fyne.WidgetRenderer {
+	objects := []fyne.CanvasObject{s.raster, s.marker.object()}
+	if s.background != nil {
+		objects = append([]fyne.CanvasObject{s.background}, objects...)
+	}
+	return &channelSliderRenderer{slider: s, objects: objects}
+}
+
+type channelSliderRenderer struct {
+	slider  *channelSlider
+	objects []fyne.CanvasObject
+}
+
+func (r *channelSliderRenderer) Layout(size fyne.Size) {
+	if r.slider.background != nil {
+		r.slider.background.Resize(size)
+	}
+	r.slider.raster.Resize(size)
+	r.slider.updateMarker(size)
+}
+
+func (r *channelSliderRenderer) MinSize() fyne.Size {
+	return r.slider.raster.MinSize()
+}
+
+func (r *channelSliderRenderer) Refresh() {
+	r.Layout(r.slider.Size())
+	r.slider.raster.Refresh()
+}
+
+func (r *channelSliderRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *channelSliderRenderer) Destroy() {}
+
+// spinEntry is a numeric entry in the range [0, max], which can be stepped with the up and down keys.
+// A typed value is applied when it is submitted or the entry loses focus.
+type spinEntry struct {
+	widget.Entry
+
+	max   float64
+	value float64
+
+	changed func(float64)
+}
+
+func newSpinEntry(max float64) *spinEntry {
+	e := &spinEntry{
+		max:     max,
+		changed: func(float64) {},
+	}
+	e.ExtendBaseWidget(e)
+	e.Validator = func(s string) error {
+		_, err := e.parse(s)
+		return err
+	}
+	e.AlwaysShowValidationError = true
+	e.OnSubmitted = func(string) {
+		e.apply()
+	}
+	return e
+}
+
+// apply calls changed with the typed value if it is valid and differs from the current value.
+func (e *spinEntry) apply() {
+	if v, err := e.parse(e.Text); err == nil && v != e.value {
+		e.value = v
+		e.changed(v)
+	}
+}
+
+func (e *spinEntry) parse(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 || e.max < v || math.IsNaN(v) {
+		return 0, strconv.ErrRange
+	}
+	return v, nil
+}
+
+func (e *spinEntry) MinSize() fyne.Size {
+	min := e.Entry.MinSize()
+	w := fyne.MeasureText("0000", theme.TextSize(), e.TextStyle).Width + theme.InnerPadding()*2
+	return fyne.NewSize(fyne.Max(min.Width, w), min.Height)
+}
+
+func (e *spinEntry) TypedKey(key *fyne.KeyEvent) {
+	switch key.Name {
+	case fyne.KeyUp:
+		e.step(1)
+	case fyne.KeyDown:
+		e.step(-1)
+	default:
+		e.Entry.TypedKey(key)
+	}
+}
+
+func (e *spinEntry) FocusLost() {
+	e.Entry.FocusLost()
+	e.apply()
+	e.setValue(e.value)
+}
+
+func (e *spinEntry) step(d float64) {
+	e.apply()
+	v := math.Max(0, math.Min(e.max, math.Round(e.value)+d))
+	e.setValue(v)
+	e.changed(v)
+}
+
+// setValue sets the value without calling changed.
+func (e *spinEntry) setValue(v float64) {
+	e.value = v
+	e.SetText(strconv.Itoa(int(math.Round(v))))
+}
@@ -0,0 +1,128 @@
+package colorpicker
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestChannelSliders(t *testing.T) {
+	test.NewTempApp(t)
+	for _, style := range allStyles {
+		p := New(200, style)
+		s := NewChannelSliders(p)
+		w := test.NewWindow(s)
+		w.Resize(fyne.NewSize(400, 400))
+
+		p.SetColor(color.NRGBA{0xff, 0x80, 0x00, 0x80})
+		want := []string{"255", "128", "0", "30", "100", "100", "50", "50"}
+		for i, e := range s.entries {
+			if e.Text != want[i] {
+				t.Errorf("style %d: %s = %q; want %q", style, allChannels[i].label(), e.Text, want[i])
+			}
+		}
+
+		var committed []color.Color
+		p.SetOnCommitted(func(c color.Color) {
+			committed = append(committed, c)
+		})
+
+		red := s.entries[0]
+		red.SetText("")
+		test.Type(red, "64")
+		if got, want := p.Color(), (color.NRGBA{0xff, 0x80, 0x00, 0x80}); got != want {
+			t.Errorf("style %d: Color() before submitting red = %v; want %v", style, got, want)
+		}
+		red.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+		if got, want := p.Color(), (color.NRGBA{0x40, 0x80, 0x00, 0x80}); got != want {
+			t.Errorf("style %d: Color() after typing red = %v; want %v", style, got, want)
+		}
+		if red.Text != "64" {
+			t.Errorf("style %d: red = %q; want %q", style, red.Text, "64")
+		}
+
+		alpha := s.entries[7]
+		alpha.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+		if _, _, _, a := p.HSVA(); notEquals(a, 0.51) {
+			t.Errorf("style %d: alpha after up key = %f; want 0.51", style, a)
+		}
+
+		hue := s.sliders[3]
+		test.TapAt(hue.raster, fyne.NewPos(hue.raster.Size().Width/2, 1))
+		if h, _, _, _ := p.HSVA(); notEqualsUint8(h, 0.5) {
+			t.Errorf("style %d: hue after tap = %f; want 0.5", style, h)
+		}
+		if s.entries[3].Text != "180" {
+			t.Errorf("style %d: H = %q; want %q", style, s.entries[3].Text, "180")
+		}
+
+		for i := 1; i <= 2; i++ {
+			hue.raster.Dragged(&fyne.DragEvent{
+				PointEvent: fyne.PointEvent{Position: fyne.NewPos(hue.raster.Size().Width/2+float32(i)*10, 1)},
+				Dragged:    fyne.NewDelta(10, 0),
+			})
+		}
+		hue.raster.DragEnd()
+		if got := len(committed); got != 4 {
+			t.Errorf("style %d: committed %d times after submit, up key, tap and drag; want 4", style, got)
+		}
+		w.Close()
+	}
+}
+
+func TestChannelSlidersOpaque(t *testing.T) {
+	test.NewTempApp(t)
+	s := NewChannelSliders(NewWithOptions(StyleHue, WithOpaque(nil)))
+	if got, want := len(s.sliders), len(allChannels)-1; got != want {
+		t.Errorf("%d sliders on an opaque picker; want %d", got, want)
+	}
+	for _, slider := range s.sliders {
+		if slider.channel == ChannelAlpha {
+			t.Error("alpha slider on an opaque picker")
+		}
+	}
+
+	s = NewChannelSliders(NewWithOptions(StyleHue, WithOpaque(nil)), ChannelAlpha)
+	if len(s.sliders) != 1 {
+		t.Errorf("%d sliders for the given channel; want 1", len(s.sliders))
+	}
+}
+
+func TestSpinEntryValidation(t *testing.T) {
+	test.NewTempApp(t)
+	e := newSpinEntry(100)
+	var changed []float64
+	e.changed = func(v float64) {
+		changed = append(changed, v)
+	}
+	e.SetText("101")
+	if e.Validate() == nil {
+		t.Errorf("Validate() = nil for %q", e.Text)
+	}
+	e.SetText("abc")
+	if e.Validate() == nil {
+		t.Errorf("Validate() = nil for %q", e.Text)
+	}
+	e.SetText("42.5")
+	if e.Validate() != nil {
+		t.Errorf("Validate() = %v for %q", e.Validate(), e.Text)
+	}
+	if len(changed) != 0 {
+		t.Errorf("changed with %v before submitting; want none", changed)
+	}
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	if e.Text != "42" {
+		t.Errorf("Text after down key = %q; want %q", e.Text, "42")
+	}
+	if want := []float64{42.5, 42}; len(changed) != 2 || changed[0] != want[0] || changed[1] != want[1] {
+		t.Errorf("changed with %v; want %v", changed, want)
+	}
+
+	e.SetText("7")
+	e.FocusLost()
+	if len(changed) != 3 || changed[2] != 7 {
+		t.Errorf("changed with %v after losing focus; want 7 last", changed)
+	}
+}
@@ -0,0 +1,118 @@
+package colorpicker
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+var swatchMinSize = fyne.NewSize(24, 24)
+
+// disabledOverlayColor returns the translucent background color laid over the colors of disabled widgets.
+func disabledOverlayColor() color.Color {
+	c := color.NRGBAModel.Convert(theme.BackgroundColor()).(color.NRGBA)
+	c.A = 0xa0
+	return c
+}
+
+// swatch displays a color over the checkered background, so that translucent colors can be told apart.
+type swatch struct {
+	widget.BaseWidget
+
+	color color.Color
+	// selected highlights the border of the swatch.
+	selected bool
+}
+
+func newSwatch(c color.Color) *swatch {
+	s := &swatch{color: c}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+func (s *swatch) setColor(c color.Color) {
+	s.color = c
+	s.Refresh()
+}
+
+func (s *swatch) setSelected(selected bool) {
+	if s.selected == selected {
+		return
+	}
+	s.selected = selected
+	s.Refresh()
+}
+
+func (s *swatch) CreateRenderer() fyne.WidgetRenderer {
+	r := &swatchRenderer{
+		swatch:     s,
+		background: newCheckeredBackground(defaultCheckerboardColors[0], defaultCheckerboardColors[1]),
+		fill:       &canvas.Rectangle{},
+		border:     &canvas.Rectangle{FillColor: transparent},
+	}
+	r.Refresh()
+	return r
+}
+
+type swatchRenderer struct {
+	swatch     *swatch
+	background *canvas.Raster
+	fill       *canvas.Rectangle
+	border     *canvas.Rectangle
+}
+
+func (r *swatchRenderer) Layout(size fyne.Size) {
+	r.background.Resize(size)
+	r.fill.Resize(size)
+	r.border.Resize(size)
+}
+
+func (r *swatchRenderer) MinSize() fyne.Size {
+	return swatchMinSize
+}
+
+func (r *swatchRenderer) Refresh() {
+	r.fill.FillColor = r.swatch.color
+	r.border.StrokeColor = theme.InputBorderColor()
+	r.border.StrokeWidth = 1
+	if r.swatch.selected {
+		r.border.StrokeColor = theme.PrimaryColor()
+		r.border.StrokeWidth = 2
+	}
+	canvas.Refresh(r.fill)
+	canvas.Refresh(r.border)
+}
+
+func (r *swatchRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.background, r.fill, r.border}
+}
+
+func (r *swatchRenderer) Destroy() {}
+
+// swatchButton is a swatch that calls tapped when it is tapped.
+type swatchButton struct {
+	*swatch
+
+	tapped func()
+}
+
+func newSwatchButton(c color.Color, tapped func()) *swatchButton {
+	b := &swatchButton{
+		swatch: &swatch{color: c},
+		tapped: tapped,
+	}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+func (b *swatchButton) Tapped(*fyne.PointEvent) {
+	b.tapped()
+}
+
+func (b *swatchButton) Cursor() desktop.Cursor {
+	return desktop.PointerCursor
+}